@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// accountMsg carries the signed-in user's account, for the account view.
+type accountMsg struct {
+	account Account
+}
+
+func fetchAccount() tea.Cmd {
+	return func() tea.Msg {
+		account, err := api.CurrentAccount(programCtx)
+		if err != nil {
+			return err
+		}
+		return accountMsg{account: account}
+	}
+}
+
+// openAccount shows who the API key belongs to, fetching the account
+// afresh each time so changed settings show up.
+func (m *model) openAccount() tea.Cmd {
+	m.loading = true
+	return tea.Batch(fetchAccount(), m.spinner.Tick)
+}
+
+func (m *model) showAccount() {
+	m.currentView = "account"
+	m.list.Title = "Account"
+	a := m.account
+	plan := "Free"
+	if a.IsUpgraded {
+		plan = "Upgraded"
+	}
+	m.setItems([]list.Item{
+		detailListItem{"Name", orNone(a.Name)},
+		detailListItem{"Email", orNone(a.Email)},
+		detailListItem{"Plan", plan},
+		detailListItem{"Cards", plural(a.CardsCreatedCount, "card") + " created"},
+		detailListItem{"Spaces", plural(len(m.spaces), "space")},
+		detailListItem{"Joined", formatTimestamp(a.CreatedAt)},
+		detailListItem{"Description", orNone(firstLine(a.Description))},
+		detailListItem{"Website", orNone(a.Website)},
+		detailListItem{"Theme", orNone(a.Theme)},
+		detailListItem{"Default card color", orNone(a.DefaultCardBackgroundColor)},
+		detailListItem{"Email notifications", onOff(a.ShouldEmailNotifications)},
+		detailListItem{"Weekly review email", onOff(a.ShouldEmailWeeklyReview)},
+		detailListItem{"Bulletin email", onOff(a.ShouldEmailBulletin)},
+		detailListItem{"User ID", a.ID},
+	})
+}
+
+// onOff shows a setting that's on or off.
+func onOff(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
+}
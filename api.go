@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
+)
+
+// api is the client every request goes through.
+var api = kinopio.NewClient(os.Getenv("KINOPIO_API_KEY"))
+
+func fetchSpaces() tea.Cmd {
+	return func() tea.Msg {
+		spaces, err := api.Spaces(programCtx)
+		if err != nil {
+			return err
+		}
+		return spacesMsg{spaces: spaces}
+	}
+}
+
+// spaceChunkSize is how many cards are decoded between progress reports
+// while a space loads.
+const spaceChunkSize = 200
+
+// spaceLoadingMsg reports progress loading a space. next waits for the
+// following report, or for the space itself.
+type spaceLoadingMsg struct {
+	spaceID   string
+	cards     int
+	bytesRead int64
+	total     int64 // -1 if unknown
+	next      tea.Cmd
+}
+
+// fetchSpaceDetails streams a space, sending spaceLoadingMsgs as its
+// cards arrive and a spaceDetailsMsg once it's all there.
+func fetchSpaceDetails(spaceID string) tea.Cmd {
+	updates := make(chan tea.Msg, 1)
+	next := func() tea.Msg { return <-updates }
+	return func() tea.Msg {
+		go func() {
+			defer close(updates)
+			cards := 0
+			space, err := api.StreamSpace(programCtx, spaceID, spaceChunkSize, func(p kinopio.SpaceProgress) {
+				cards += len(p.Cards)
+				// Skip reports the UI hasn't caught up with; only the latest
+				// matters.
+				select {
+				case updates <- spaceLoadingMsg{spaceID: spaceID, cards: cards, bytesRead: p.BytesRead, total: p.Total, next: next}:
+				default:
+				}
+			})
+			if err != nil {
+				updates <- err
+				return
+			}
+			updates <- spaceDetailsMsg{Space: space}
+		}()
+		return next()
+	}
+}
+
+type spaceCreatedMsg struct {
+	Space Space
+}
+
+// createSpace creates an empty space with the given name.
+func createSpace(name string) tea.Cmd {
+	return func() tea.Msg {
+		space, err := api.CreateSpace(programCtx, newID(), name)
+		if err != nil {
+			return err
+		}
+		return spaceCreatedMsg{Space: space}
+	}
+}
+
+// apiCmd runs a request whose only result is whether it failed.
+func apiCmd(request func(ctx context.Context) error) tea.Cmd {
+	return func() tea.Msg {
+		if err := request(programCtx); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// updateConnection PATCHes the given fields of a connection. fields must
+// include the connection's id.
+func updateConnection(fields map[string]interface{}) tea.Cmd {
+	return apiCmd(func(ctx context.Context) error { return api.UpdateConnection(ctx, fields) })
+}
+
+// updateCard PATCHes the given fields of a card. fields must include the
+// card's id.
+func updateCard(fields map[string]interface{}) tea.Cmd {
+	return sendWrite(queuedWrite{Kind: "update", Fields: []map[string]interface{}{fields}})
+}
+
+// updateCards PATCHes several cards in a single request. Each entry must
+// include the card's id.
+func updateCards(cards []map[string]interface{}) tea.Cmd {
+	return sendWrite(queuedWrite{Kind: "update", Fields: cards})
+}
+
+func createBoxes(spaceID string, boxes []Box) tea.Cmd {
+	return apiCmd(func(ctx context.Context) error { return postBoxes(ctx, spaceID, boxes) })
+}
+
+// postBoxes saves new boxes to a space, one request per box.
+func postBoxes(ctx context.Context, spaceID string, boxes []Box) error {
+	for _, box := range boxes {
+		if err := api.CreateBox(ctx, spaceID, box); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeCard removes a card. Removed cards stay in the space's removed
+// cards and can be restored.
+func removeCard(cardID string) tea.Cmd {
+	return sendWrite(queuedWrite{Kind: "remove", CardID: cardID})
+}
+
+type cardCreatedMsg struct {
+	Card Card
+}
+
+// cardCreateFailedMsg reports that a card added to the list optimistically
+// couldn't be saved.
+type cardCreateFailedMsg struct {
+	cardID string
+	err    error
+}
+
+// createCard saves a card that has already been added to the list. If the
+// API can't be reached, the card stays in the list and is queued instead.
+func createCard(spaceID string, card Card) tea.Cmd {
+	return func() tea.Msg {
+		w := queuedWrite{Kind: "create", SpaceID: spaceID, Cards: []Card{card}}
+		if writes.len() > 0 {
+			return writes.add(w)
+		}
+		saved, err := api.CreateCard(programCtx, spaceID, card)
+		if tryLater(err) {
+			return writes.add(w.maybeSent(err))
+		}
+		if err != nil {
+			return cardCreateFailedMsg{cardID: card.ID, err: err}
+		}
+		return cardCreatedMsg{Card: saved}
+	}
+}
+
+func createCards(spaceID string, cards []Card) tea.Cmd {
+	return sendWrite(queuedWrite{Kind: "create", SpaceID: spaceID, Cards: cards})
+}
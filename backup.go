@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runBackup saves every space, with all its cards, boxes and connections,
+// to a timestamped JSON file in a directory.
+func runBackup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	dir := fs.String("dir", "kinopio-backup", "directory to save the backups in")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("usage: kinopio-tui backup [--dir <directory>]")
+	}
+
+	ctx := context.Background()
+	spaces, err := api.Spaces(ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return fmt.Errorf("error creating backup directory: %v", err)
+	}
+	stamp := time.Now().Format("20060102-150405")
+	for _, listed := range spaces {
+		space, err := api.Space(ctx, listed.ID)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(space, "", "  ")
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s-%s-%s.json", exportFileName(space, ""), space.ID, stamp)
+		path := filepath.Join(*dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("error writing backup: %v", err)
+		}
+		fmt.Printf("Backed up %s (%s) to %s\n", space.Name, plural(len(space.Cards), "card"), path)
+	}
+	return nil
+}
+
+// runRestore recreates a space from a backup as a new space. Everything in
+// it gets a new ID, so restoring never touches the original, if it still
+// exists.
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	name := fs.String("name", "", "name for the restored space (default: the backed up name)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: kinopio-tui restore <backup file> [--name <space name>]")
+	}
+
+	data, err := os.ReadFile(positional[0])
+	if err != nil {
+		return fmt.Errorf("error reading backup: %v", err)
+	}
+	var backup Space
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return fmt.Errorf("error parsing backup %s: %v", positional[0], err)
+	}
+	if *name == "" {
+		*name = backup.Name
+	}
+
+	ctx := context.Background()
+	space, err := api.CreateSpace(ctx, newID(), *name)
+	if err != nil {
+		return err
+	}
+	if err := restoreItems(ctx, space.ID, backup); err != nil {
+		return fmt.Errorf("%v\nThe space was partly restored: %s", err, spaceURL(space))
+	}
+	fmt.Printf("Restored %s (%s) to %s\n", space.Name, plural(len(backup.Cards), "card"), spaceURL(space))
+	return nil
+}
+
+// restoreItems recreates a backed up space's contents in another space,
+// under new IDs.
+func restoreItems(ctx context.Context, spaceID string, backup Space) error {
+	ids := map[string]string{}
+	renew := func(id string) string {
+		if id != "" && ids[id] == "" {
+			ids[id] = newID()
+		}
+		return ids[id]
+	}
+
+	for _, t := range backup.ConnectionTypes {
+		t.ID = renew(t.ID)
+		if err := api.CreateConnectionType(ctx, spaceID, t); err != nil {
+			return err
+		}
+	}
+	boxes := make([]Box, len(backup.Boxes))
+	for i, box := range backup.Boxes {
+		box.ID = renew(box.ID)
+		boxes[i] = box
+	}
+	if err := postBoxes(ctx, spaceID, boxes); err != nil {
+		return err
+	}
+	cards := make([]Card, len(backup.Cards))
+	for i, card := range backup.Cards {
+		card.ID = renew(card.ID)
+		cards[i] = card
+	}
+	if len(cards) > 0 {
+		if err := api.CreateCards(ctx, spaceID, cards); err != nil {
+			return err
+		}
+	}
+	for _, conn := range backup.Connections {
+		conn.ID = renew(conn.ID)
+		conn.ConnectionTypeID = renew(conn.ConnectionTypeID)
+		conn.StartItemID = renew(conn.StartItemID)
+		conn.EndItemID = renew(conn.EndItemID)
+		if err := api.CreateConnection(ctx, spaceID, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type bookmark struct {
+	title, url string
+}
+
+// bookmarkFolder is a folder of bookmarks. Nested folders are flattened
+// into names like "Parent / Child"; bookmarks outside any folder are in a
+// folder with an empty name.
+type bookmarkFolder struct {
+	name      string
+	bookmarks []bookmark
+}
+
+var bookmarkTokenPattern = regexp.MustCompile(`(?is)<h3[^>]*>(.*?)</h3>|<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>|</dl>`)
+
+// parseBookmarks reads the Netscape bookmark file format that browsers
+// export.
+func parseBookmarks(data string) []bookmarkFolder {
+	var (
+		path    []string
+		folders []bookmarkFolder
+		index   = map[string]int{}
+	)
+	for _, match := range bookmarkTokenPattern.FindAllStringSubmatch(data, -1) {
+		switch {
+		case match[1] != "":
+			path = append(path, html.UnescapeString(strings.TrimSpace(match[1])))
+		case match[2] != "":
+			name := strings.Join(path, " / ")
+			i, ok := index[name]
+			if !ok {
+				i = len(folders)
+				index[name] = i
+				folders = append(folders, bookmarkFolder{name: name})
+			}
+			title := html.UnescapeString(strings.TrimSpace(match[3]))
+			folders[i].bookmarks = append(folders[i].bookmarks, bookmark{title: title, url: html.UnescapeString(match[2])})
+		default: // </DL> closes the current folder
+			if len(path) > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+	}
+	return folders
+}
+
+// bookmarkColumns lays bookmarks out with one column per folder. Link
+// cards show the bookmark's title above its URL.
+func bookmarkColumns(folders []bookmarkFolder) []column {
+	columns := make([]column, len(folders))
+	for i, folder := range folders {
+		columns[i].name = folder.name
+		for _, b := range folder.bookmarks {
+			name := b.url
+			if b.title != "" && b.title != b.url {
+				name = b.title + "\n" + b.url
+			}
+			columns[i].cards = append(columns[i].cards, name)
+		}
+	}
+	return columns
+}
+
+// importBookmarksPrompt asks for a bookmarks export file and imports it
+// into the selected space.
+func (m *model) importBookmarksPrompt() tea.Cmd {
+	return m.openPrompt("Bookmarks file", "", func(path string) tea.Cmd {
+		data, err := os.ReadFile(expandHome(strings.TrimSpace(path)))
+		if err != nil {
+			return func() tea.Msg { return fmt.Errorf("error reading bookmarks: %v", err) }
+		}
+		boxes, cards := layoutColumns(bookmarkColumns(parseBookmarks(string(data))))
+		if len(cards) == 0 {
+			return func() tea.Msg { return fmt.Errorf("no bookmarks found in %s", path) }
+		}
+		m.selectedSpace.Boxes = append(m.selectedSpace.Boxes, boxes...)
+		m.selectedSpace.Cards = append(m.selectedSpace.Cards, cards...)
+		m.setCardItems()
+		return tea.Sequence(createBoxes(m.selectedSpace.ID, boxes), createCards(m.selectedSpace.ID, cards))
+	})
+}
+
+// expandHome expands a leading ~ to the user's home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + rest
+		}
+	}
+	return path
+}
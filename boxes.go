@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Size of boxes created from the boxes view. They can be resized after.
+const (
+	newBoxWidth  = 400
+	newBoxHeight = 300
+)
+
+// selectedBox returns the highlighted box in the boxes list.
+func (m *model) selectedBox() (Box, bool) {
+	item, ok := m.list.SelectedItem().(boxListItem)
+	return item.Box, ok
+}
+
+// replaceBox swaps the local copy of a box for an updated one.
+func (m *model) replaceBox(box Box) {
+	for i, b := range m.selectedSpace.Boxes {
+		if b.ID == box.ID {
+			m.selectedSpace.Boxes[i] = box
+		}
+	}
+	m.showBoxes()
+}
+
+// newBox prompts for a name and adds a box below everything else in the
+// space.
+func (m *model) newBox() tea.Cmd {
+	return m.openPrompt("New box", "", func(name string) tea.Cmd {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil
+		}
+		x, y := nextCardPosition(m.selectedSpace.Cards)
+		for _, box := range m.selectedSpace.Boxes {
+			y = max(y, box.Y+box.ResizeHeight+bulkAddSpacing)
+		}
+		box := Box{ID: newID(), Name: name, X: x, Y: y, ResizeWidth: newBoxWidth, ResizeHeight: newBoxHeight}
+		m.selectedSpace.Boxes = append(m.selectedSpace.Boxes, box)
+		m.showBoxes()
+		spaceID := m.selectedSpace.ID
+		return apiCmd(func(ctx context.Context) error { return api.CreateBox(ctx, spaceID, box) })
+	})
+}
+
+// renameBox prompts for a new name for the highlighted box.
+func (m *model) renameBox() tea.Cmd {
+	box, ok := m.selectedBox()
+	if !ok {
+		return nil
+	}
+	return m.openPrompt("Rename box", box.Name, func(name string) tea.Cmd {
+		box.Name = name
+		m.replaceBox(box)
+		return apiCmd(func(ctx context.Context) error {
+			return api.UpdateBox(ctx, map[string]interface{}{"id": box.ID, "name": name})
+		})
+	})
+}
+
+// resizeBox prompts for the highlighted box's size as "width×height".
+func (m *model) resizeBox() tea.Cmd {
+	box, ok := m.selectedBox()
+	if !ok {
+		return nil
+	}
+	current := fmt.Sprintf("%dx%d", box.ResizeWidth, box.ResizeHeight)
+	return m.openPrompt("Size (width x height)", current, func(value string) tea.Cmd {
+		width, height, err := parseSize(value)
+		if err != nil {
+			return func() tea.Msg { return err }
+		}
+		box.ResizeWidth, box.ResizeHeight = width, height
+		m.replaceBox(box)
+		return apiCmd(func(ctx context.Context) error {
+			return api.UpdateBox(ctx, map[string]interface{}{"id": box.ID, "resizeWidth": width, "resizeHeight": height})
+		})
+	})
+}
+
+// parseSize reads a size like "400x300" or "400 × 300".
+func parseSize(value string) (int, int, error) {
+	value = strings.NewReplacer("×", "x", "X", "x", " ", "").Replace(value)
+	w, h, ok := strings.Cut(value, "x")
+	width, werr := strconv.Atoi(w)
+	height, herr := strconv.Atoi(h)
+	if !ok || werr != nil || herr != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid size %q: expected width x height, e.g. 400x300", value)
+	}
+	return width, height, nil
+}
+
+// deleteBox removes the highlighted box after confirmation. Its cards
+// stay where they are.
+func (m *model) deleteBox() {
+	box, ok := m.selectedBox()
+	if !ok {
+		return
+	}
+	m.askConfirm(fmt.Sprintf("Delete the box %q?\nIts cards will be kept.", box.Name), true, func() tea.Cmd {
+		for i, b := range m.selectedSpace.Boxes {
+			if b.ID == box.ID {
+				m.selectedSpace.Boxes = append(m.selectedSpace.Boxes[:i:i], m.selectedSpace.Boxes[i+1:]...)
+				break
+			}
+		}
+		m.showBoxes()
+		return apiCmd(func(ctx context.Context) error { return api.RemoveBox(ctx, box.ID) })
+	})
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkRow is the field name of cardDetails rows that hold a link, which o
+// opens.
+const linkRow = "link"
+
+// openURL opens a URL with the platform's opener, normally in the default
+// browser.
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("error opening %s: %v", url, err)
+		}
+		go cmd.Wait() //nolint: errcheck
+		return nil
+	}
+}
+
+// cardLinks lists the links in a card: the URLs in its name, then its
+// URL preview's if that's another one.
+func cardLinks(card Card) []string {
+	links := cardURLs(card.Name)
+	if card.URLPreviewURL == "" {
+		return links
+	}
+	for _, link := range links {
+		if link == card.URLPreviewURL {
+			return links
+		}
+	}
+	return append(links, card.URLPreviewURL)
+}
+
+// openCardLink opens the link under the cursor in cardDetails, or the
+// card's only link wherever the cursor is.
+func (m *model) openCardLink() tea.Cmd {
+	if row := m.cardTable.SelectedRow(); len(row) == 2 && row[0] == linkRow {
+		return tea.Batch(openURL(row[1]), m.toast("Opening "+row[1]))
+	}
+	switch links := cardLinks(m.selectedCard); len(links) {
+	case 0:
+		return m.toast("This card has no links")
+	case 1:
+		return tea.Batch(openURL(links[0]), m.toast("Opening "+links[0]))
+	default:
+		return m.toast("Move to a link to open it")
+	}
+}
+
+// openCardInKinopio opens the selected card in the web app.
+func (m *model) openCardInKinopio() tea.Cmd {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return nil
+	}
+	return tea.Batch(openURL(cardURL(m.selectedSpace, card)), m.toast("Opening the card in Kinopio"))
+}
+
+// openSpaceInKinopio opens the highlighted space in the spaces list, or
+// else the open space, in the web app.
+func (m *model) openSpaceInKinopio() tea.Cmd {
+	space := m.selectedSpace
+	if m.currentView == "list" {
+		item, ok := m.list.SelectedItem().(listItem)
+		if !ok {
+			return nil
+		}
+		space = item.Space
+	}
+	if space.Url == "" {
+		return nil
+	}
+	return tea.Batch(openURL(spaceURL(space)), m.toast("Opening "+space.Name+" in Kinopio"))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleSelected adds the highlighted card to the selection, or takes it
+// out, and moves on to the next card so a run can be marked quickly.
+func (m *model) toggleSelected() {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return
+	}
+	if m.selected[card.ID] {
+		delete(m.selected, card.ID)
+	} else {
+		m.selected[card.ID] = true
+	}
+	m.refreshCardItems()
+	m.list.CursorDown()
+}
+
+// showBulkActions lists what can be done to all the selected cards at once.
+func (m *model) showBulkActions() {
+	cards := m.selectedCards()
+	if len(cards) == 0 {
+		return
+	}
+	m.currentView = "bulkActions"
+	m.list.Title = fmt.Sprintf("%s → %s selected", m.selectedSpace.Name, plural(len(cards), "card"))
+	m.setItems([]list.Item{
+		detailListItem{"Remove", "Remove the cards from the space"},
+		detailListItem{"Background color", "Set the cards' background color"},
+		detailListItem{"Add tag", "Add a [[tag]] to each card"},
+		detailListItem{"Move to space", "Move the cards to another space"},
+		detailListItem{"Clear selection", "Deselect all the cards"},
+	})
+}
+
+func (m *model) runBulkAction(action string) tea.Cmd {
+	switch action {
+	case "Remove":
+		m.bulkRemove()
+	case "Background color":
+		return m.bulkBackgroundColor()
+	case "Add tag":
+		return m.bulkAddTag()
+	case "Move to space":
+		m.showCards()
+		m.pickTransferTarget(true)
+	case "Clear selection":
+		m.selected = map[string]bool{}
+		m.showCards()
+	}
+	return nil
+}
+
+// bulkRemove removes the selected cards after confirmation.
+func (m *model) bulkRemove() {
+	cards := m.selectedCards()
+	message := fmt.Sprintf("Remove %s?\nYou can restore them from Removed cards.", plural(len(cards), "card"))
+	m.askConfirm(message, true, func() tea.Cmd {
+		m.recordRemove("bulk removal", cards...)
+		cmds := make([]tea.Cmd, len(cards))
+		for i, card := range cards {
+			m.removeLocalCard(card.ID)
+			cmds[i] = removeCard(card.ID)
+		}
+		m.showCards()
+		return tea.Batch(cmds...)
+	})
+}
+
+// bulkBackgroundColor opens the color picker over the cards list, to give
+// every selected card the color picked.
+func (m *model) bulkBackgroundColor() tea.Cmd {
+	cards := m.selectedCards()
+	m.showCards()
+	m.pickColor(cards)
+	return nil
+}
+
+// bulkAddTag prompts for a tag and appends it to the name of every
+// selected card that doesn't have it yet.
+func (m *model) bulkAddTag() tea.Cmd {
+	return m.openPrompt("Add tag", "", func(tag string) tea.Cmd {
+		tag = strings.Trim(strings.TrimSpace(tag), "[]")
+		if tag == "" {
+			return nil
+		}
+		var cards []Card
+		var fields []map[string]interface{}
+		for _, card := range m.selectedCards() {
+			if m.hasTag(card, tag) {
+				continue
+			}
+			card.Name = strings.TrimRight(card.Name, " ") + " [[" + tag + "]]"
+			cards = append(cards, card)
+			fields = append(fields, map[string]interface{}{"id": card.ID, "name": card.Name})
+		}
+		m.showCards()
+		if len(cards) == 0 {
+			return nil
+		}
+		m.recordUpdate("tagging", cards...)
+		m.replaceCards(cards...)
+		return updateCards(fields)
+	})
+}
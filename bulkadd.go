@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bulkAddSpacing is the vertical gap, in canvas pixels, between cards
+// created by a bulk add.
+const bulkAddSpacing = 50
+
+// bulkAddForm turns a pasted block of text into one card per line,
+// stacked vertically from a starting position.
+type bulkAddForm struct {
+	position  textinput.Model
+	text      textarea.Model
+	focusText bool
+}
+
+func newBulkAddForm(width, height int) *bulkAddForm {
+	position := textinput.New()
+	position.Prompt = "Start at (x,y): "
+	position.SetValue("100,100")
+
+	text := textarea.New()
+	text.Placeholder = "One card per line…"
+	text.CharLimit = 0
+	text.MaxHeight = 0
+	text.SetWidth(width - 2)
+	text.SetHeight(max(height-8, 3))
+	text.Focus()
+
+	return &bulkAddForm{position: position, text: text, focusText: true}
+}
+
+// parsePosition reads the "x,y" start position.
+func (f *bulkAddForm) parsePosition() (int, int, error) {
+	xs, ys, ok := strings.Cut(f.position.Value(), ",")
+	x, xErr := strconv.Atoi(strings.TrimSpace(xs))
+	y, yErr := strconv.Atoi(strings.TrimSpace(ys))
+	if !ok || xErr != nil || yErr != nil {
+		return 0, 0, fmt.Errorf("invalid start position %q, expected x,y", f.position.Value())
+	}
+	return x, y, nil
+}
+
+// lines returns the non-blank lines of the text area.
+func (f *bulkAddForm) lines() []string {
+	var lines []string
+	for _, line := range strings.Split(f.text.Value(), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines
+}
+
+func (f *bulkAddForm) View() string {
+	title := lipgloss.NewStyle().Bold(true).Render("Bulk add cards")
+	help := dimStyle().
+		Render(fmt.Sprintf("%s · tab to switch fields · ctrl+s to create · esc to cancel", plural(len(f.lines()), "card")))
+	return strings.Join([]string{title, "", f.position.View(), "", f.text.View(), "", help}, "\n")
+}
+
+func (m *model) openBulkAdd() tea.Cmd {
+	m.bulkAdd = newBulkAddForm(m.width, m.height)
+	return textarea.Blink
+}
+
+func (m *model) updateBulkAdd(msg tea.KeyMsg) tea.Cmd {
+	f := m.bulkAdd
+	switch msg.String() {
+	case "esc":
+		m.bulkAdd = nil
+		return nil
+	case "tab", "shift+tab":
+		f.focusText = !f.focusText
+		if f.focusText {
+			f.position.Blur()
+			return f.text.Focus()
+		}
+		f.text.Blur()
+		return f.position.Focus()
+	case "ctrl+s":
+		x, y, err := f.parsePosition()
+		if err != nil {
+			return func() tea.Msg { return err }
+		}
+		m.bulkAdd = nil
+		return m.createStack(f.lines(), x, y)
+	}
+
+	var cmd tea.Cmd
+	if f.focusText {
+		f.text, cmd = f.text.Update(msg)
+	} else {
+		f.position, cmd = f.position.Update(msg)
+	}
+	return cmd
+}
+
+// createStack creates a card for each name, stacked downwards from (x, y).
+// The cards are added to the list right away and saved in one request.
+func (m *model) createStack(names []string, x, y int) tea.Cmd {
+	if len(names) == 0 {
+		return nil
+	}
+	cards := make([]Card, len(names))
+	for i, name := range names {
+		cards[i] = Card{ID: newID(), Name: name, X: x, Y: y + i*bulkAddSpacing}
+	}
+	m.selectedSpace.Cards = append(m.selectedSpace.Cards, cards...)
+	m.recordCreate("bulk add", cards...)
+	m.setCardItems()
+	return createCards(m.selectedSpace.ID, cards)
+}
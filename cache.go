@@ -0,0 +1,152 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The offline cache keeps the last fetched copy of the spaces list and of
+// each opened space in the user's cache directory, e.g.
+// ~/.cache/kinopio-tui. The TUI starts from it and refreshes in the
+// background. It's only ever a speed-up, so cache errors are ignored.
+
+type spaceRefreshedMsg struct {
+	Space Space
+}
+
+// refreshFailedMsg reports that cached data couldn't be refreshed, most
+// likely because the API is unreachable.
+type refreshFailedMsg struct {
+	err error
+}
+
+func cachePath(name string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kinopio-tui", name+".json"), nil
+}
+
+// readCache decodes a cache file into v, reporting whether there was one.
+func readCache(name string, v interface{}) bool {
+	path, err := cachePath(name)
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// writeCache saves v to a cache file in the background.
+func writeCache(name string, v interface{}) tea.Cmd {
+	return func() tea.Msg {
+		path, err := cachePath(name)
+		if err != nil {
+			return nil
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		if os.MkdirAll(filepath.Dir(path), 0o755) == nil {
+			os.WriteFile(path, data, 0o644)
+		}
+		return nil
+	}
+}
+
+func spaceCacheName(spaceID string) string {
+	return "space-" + spaceID
+}
+
+func loadCachedSpaces() ([]Space, bool) {
+	var spaces []Space
+	ok := readCache("spaces", &spaces)
+	return spaces, ok && len(spaces) > 0
+}
+
+func loadCachedSpace(spaceID string) (Space, bool) {
+	var space Space
+	ok := readCache(spaceCacheName(spaceID), &space)
+	return space, ok && space.ID == spaceID
+}
+
+// refreshSpaces fetches the spaces list behind a cached copy.
+func refreshSpaces() tea.Cmd {
+	return func() tea.Msg {
+		spaces, err := api.Spaces(programCtx)
+		if err != nil {
+			return refreshFailedMsg{err}
+		}
+		return spacesMsg{spaces: spaces}
+	}
+}
+
+// refreshSpace fetches a space that was opened from the cache.
+func refreshSpace(spaceID string) tea.Cmd {
+	return func() tea.Msg {
+		space, err := api.Space(programCtx, spaceID)
+		if err != nil {
+			return refreshFailedMsg{err}
+		}
+		return spaceRefreshedMsg{Space: space}
+	}
+}
+
+// spaceRefreshed swaps in the fetched copy of the open space, redrawing
+// the views that just list its contents. Views the user may be working
+// in, like card details, pick it up when next shown.
+func (m *model) spaceRefreshed(space Space) tea.Cmd {
+	if _, ok := m.spaceCache[space.ID]; ok {
+		m.spaceCache[space.ID] = space
+	}
+	if space.ID != m.selectedSpace.ID || reflect.DeepEqual(space, m.selectedSpace) {
+		return nil
+	}
+	m.selectedSpace = space
+	switch m.currentView {
+	case "details", "cards", "boxes", "connections", "tags", "graph":
+		m.keepCursor(func() { m.restoreLocation(m.location()) })
+	}
+	return writeCache(spaceCacheName(space.ID), space)
+}
+
+// etagCache keeps API responses with their ETags alongside the offline
+// cache, so requests for things that haven't changed get a 304 instead of
+// the whole response.
+type etagCache struct{}
+
+type etagEntry struct {
+	Path string          `json:"path"`
+	ETag string          `json:"etag"`
+	Body json.RawMessage `json:"body"`
+}
+
+func etagCacheName(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return "etag-" + hex.EncodeToString(sum[:8])
+}
+
+func (etagCache) Get(path string) (string, []byte, bool) {
+	var entry etagEntry
+	if !readCache(etagCacheName(path), &entry) || entry.Path != path {
+		return "", nil, false
+	}
+	return entry.ETag, entry.Body, true
+}
+
+func (etagCache) Set(path, etag string, body []byte) {
+	if json.Valid(body) {
+		writeCache(etagCacheName(path), etagEntry{Path: path, ETag: etag, Body: body})()
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Canvas pixels per terminal cell. Cells are about twice as tall as they
+// are wide, so y is scaled down more.
+const (
+	canvasScaleX       = 10
+	canvasScaleY       = 20
+	canvasCardWidth    = 20 // Cells given to a card whose width is unknown
+	canvasMargin       = 2
+	canvasPanFastCells = 10
+)
+
+// canvasCard is where a card is drawn on the canvas grid.
+type canvasCard struct {
+	card     Card
+	row, col int
+	width    int
+}
+
+// canvasView draws a space spatially: cards at their scaled positions and
+// boxes as bordered regions, with a cursor that pans the view.
+type canvasView struct {
+	grid     [][]rune
+	cards    []canvasCard      // In reading order
+	colors   map[[2]int]string // Card colors, keyed by the row and column of their marker
+	row, col int               // The cursor
+	xOffset  int
+	viewport viewport.Model
+}
+
+func newCanvasView(space Space, cards []Card) *canvasView {
+	minX, minY := 0, 0
+	for i, card := range cards {
+		if i == 0 || card.X < minX {
+			minX = card.X
+		}
+		if i == 0 || card.Y < minY {
+			minY = card.Y
+		}
+	}
+	for _, box := range space.Boxes {
+		minX, minY = min(minX, box.X), min(minY, box.Y)
+	}
+	toCol := func(x int) int { return (x-minX)/canvasScaleX + canvasMargin }
+	toRow := func(y int) int { return (y-minY)/canvasScaleY + canvasMargin }
+
+	c := &canvasView{viewport: viewport.New(0, 0), colors: map[[2]int]string{}}
+	for _, box := range space.Boxes {
+		top, left := toRow(box.Y), toCol(box.X)
+		bottom, right := toRow(box.Y+box.ResizeHeight), toCol(box.X+box.ResizeWidth)
+		c.drawBox(top, left, max(bottom, top+2), max(right, left+2), box.Name)
+	}
+	for _, card := range sortCards(cards, sortReading) {
+		width := canvasCardWidth
+		if card.Width > 0 {
+			width = max(4, card.Width/canvasScaleX)
+		}
+		placed := canvasCard{card: card, row: toRow(card.Y), col: toCol(card.X), width: width}
+		c.draw(placed.row, placed.col, ansi.Truncate("▪ "+firstLine(card.Name), width, "…"))
+		if card.BackgroundColor != "" {
+			c.colors[[2]int{placed.row, placed.col}] = card.BackgroundColor
+		} else {
+			delete(c.colors, [2]int{placed.row, placed.col})
+		}
+		c.cards = append(c.cards, placed)
+	}
+	if len(c.cards) > 0 {
+		c.row, c.col = c.cards[0].row, c.cards[0].col
+	}
+	return c
+}
+
+// draw writes s onto the grid at (row, col), growing it as needed. Wide
+// characters are replaced, since each cell holds exactly one column.
+func (c *canvasView) draw(row, col int, s string) {
+	for len(c.grid) <= row {
+		c.grid = append(c.grid, nil)
+	}
+	for _, r := range s {
+		if ansi.StringWidth(string(r)) != 1 {
+			r = '·'
+		}
+		for len(c.grid[row]) <= col {
+			c.grid[row] = append(c.grid[row], ' ')
+		}
+		c.grid[row][col] = r
+		col++
+	}
+}
+
+func (c *canvasView) drawBox(top, left, bottom, right int, name string) {
+	width := right - left - 1
+	c.draw(top, left, "┌"+strings.Repeat("─", width)+"┐")
+	c.draw(bottom, left, "└"+strings.Repeat("─", width)+"┘")
+	for row := top + 1; row < bottom; row++ {
+		c.draw(row, left, "│")
+		c.draw(row, right, "│")
+	}
+	c.draw(top, left+2, ansi.Truncate(" "+name+" ", max(0, width-3), "…"))
+}
+
+// cardAtCursor returns the card drawn under the cursor, preferring the one
+// on top.
+func (c *canvasView) cardAtCursor() (Card, bool) {
+	var found Card
+	ok := false
+	for _, placed := range c.cards {
+		if placed.row == c.row && c.col >= placed.col && c.col < placed.col+placed.width {
+			if !ok || placed.card.Z > found.Z {
+				found, ok = placed.card, true
+			}
+		}
+	}
+	return found, ok
+}
+
+// jump moves the cursor to the next or previous card in reading order.
+func (c *canvasView) jump(step int) {
+	if len(c.cards) == 0 {
+		return
+	}
+	i := sort.Search(len(c.cards), func(i int) bool {
+		p := c.cards[i]
+		return p.row > c.row || (p.row == c.row && p.col >= c.col)
+	})
+	if step > 0 && i < len(c.cards) && c.cards[i].row == c.row && c.cards[i].col == c.col {
+		i++
+	}
+	if step < 0 {
+		i--
+	}
+	i = (i%len(c.cards) + len(c.cards)) % len(c.cards)
+	c.row, c.col = c.cards[i].row, c.cards[i].col
+}
+
+func (c *canvasView) move(rows, cols int) {
+	c.row = max(0, c.row+rows)
+	c.col = max(0, c.col+cols)
+}
+
+// render redraws the visible part of the canvas into the viewport,
+// scrolling to keep the cursor in view.
+func (c *canvasView) render(width, height int) {
+	c.viewport.Width, c.viewport.Height = width, height
+	if c.col < c.xOffset {
+		c.xOffset = c.col
+	} else if c.col >= c.xOffset+width {
+		c.xOffset = c.col - width + 1
+	}
+
+	cursor := lipgloss.NewStyle().Reverse(true)
+	lines := make([]string, max(len(c.grid), c.row+1))
+	for row := range lines {
+		var cells []rune
+		if row < len(c.grid) {
+			cells = c.grid[row]
+		}
+		var b strings.Builder
+		for col := c.xOffset; col < c.xOffset+width; col++ {
+			r := ' '
+			if col < len(cells) {
+				r = cells[col]
+			}
+			color, colored := c.colors[[2]int{row, col}]
+			switch {
+			case row == c.row && col == c.col:
+				b.WriteString(cursor.Render(string(r)))
+			case colored && r == '▪' && swatch(color) != "":
+				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(string(r)))
+			default:
+				b.WriteRune(r)
+			}
+		}
+		lines[row] = strings.TrimRight(b.String(), " ")
+	}
+	c.viewport.SetContent(strings.Join(lines, "\n"))
+
+	if c.row < c.viewport.YOffset {
+		c.viewport.SetYOffset(c.row)
+	} else if c.row >= c.viewport.YOffset+height {
+		c.viewport.SetYOffset(c.row - height + 1)
+	}
+}
+
+func (m *model) showCanvas() {
+	m.currentView = "canvas"
+	m.canvas = newCanvasView(m.selectedSpace, m.commentFilter(m.selectedSpace.Cards))
+}
+
+// updateCanvas handles a key press in the canvas view. Like the kanban
+// board, it lets global keys fall through.
+func (m *model) updateCanvas(msg tea.KeyMsg) (bool, tea.Cmd) {
+	c := m.canvas
+	switch {
+	case key.Matches(msg, keys.Left):
+		c.move(0, -1)
+	case key.Matches(msg, keys.Right):
+		c.move(0, 1)
+	case key.Matches(msg, keys.Up):
+		c.move(-1, 0)
+	case key.Matches(msg, keys.Down):
+		c.move(1, 0)
+	case key.Matches(msg, keys.MoveLeft):
+		c.move(0, -canvasPanFastCells)
+	case key.Matches(msg, keys.MoveRight):
+		c.move(0, canvasPanFastCells)
+	case key.Matches(msg, keys.MoveUp):
+		c.move(-canvasPanFastCells, 0)
+	case key.Matches(msg, keys.MoveDown):
+		c.move(canvasPanFastCells, 0)
+	case key.Matches(msg, keys.NextCard):
+		c.jump(1)
+	case key.Matches(msg, keys.PrevCard):
+		c.jump(-1)
+	case key.Matches(msg, keys.Enter):
+		if card, ok := c.cardAtCursor(); ok {
+			m.selectedCard = card
+			m.currentView = "cardDetails"
+			return true, m.showCardDetails()
+		}
+	case key.Matches(msg, keys.Canvas, keys.Back, keys.Cancel):
+		m.canvas = nil
+		return true, m.popView()
+	case key.Matches(msg, keys.Quit, keys.Help, keys.Palette, keys.SwitchSpace, keys.Notifications, keys.HistoryBack, keys.HistoryForward, keys.Inbox, keys.Journal, keys.Undo, keys.Redo, keys.Refresh):
+		return false, nil
+	}
+	return true, nil
+}
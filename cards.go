@@ -0,0 +1,125 @@
+package main
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectedListCard returns the card the user is acting on: the open card
+// in cardDetails, or the highlighted card in the cards list.
+func (m *model) selectedListCard() (Card, bool) {
+	if m.currentView == "cardDetails" {
+		return m.selectedCard, true
+	}
+	if item, ok := m.list.SelectedItem().(cardListItem); ok {
+		return item.Card, true
+	}
+	return Card{}, false
+}
+
+// replaceCards swaps the local copies of cards for updated ones and
+// refreshes whichever view is showing them.
+func (m *model) replaceCards(cards ...Card) {
+	updated := make(map[string]Card, len(cards))
+	for _, card := range cards {
+		updated[card.ID] = card
+	}
+	for i, c := range m.selectedSpace.Cards {
+		if card, ok := updated[c.ID]; ok {
+			m.selectedSpace.Cards[i] = card
+		}
+	}
+	if card, ok := updated[m.selectedCard.ID]; ok {
+		m.selectedCard = card
+	}
+	switch m.currentView {
+	case "cards":
+		m.setCardItems()
+	case "cardDetails":
+		m.showCardDetails()
+	}
+}
+
+// newCard prompts for a name and adds a card below the space's other
+// cards. The card shows up in the list straight away and is swapped for
+// the server's copy once it's saved.
+func (m *model) newCard() tea.Cmd {
+	return m.openPrompt("New card", "", func(name string) tea.Cmd {
+		if strings.TrimSpace(name) == "" {
+			return nil
+		}
+		x, y := nextCardPosition(m.selectedSpace.Cards)
+		card := Card{ID: newID(), Name: name, X: x, Y: y, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		m.selectedSpace.Cards = append(m.selectedSpace.Cards, card)
+		m.recordCreate("new card", card)
+		m.boxFilter = nil
+		m.tagFilter = ""
+		m.showCards()
+		m.selectCard(card.ID)
+		return createCard(m.selectedSpace.ID, card)
+	})
+}
+
+// selectCard moves the cursor to a card in the cards list.
+func (m *model) selectCard(cardID string) {
+	for i, item := range m.list.Items() {
+		if item, ok := item.(cardListItem); ok && item.Card.ID == cardID {
+			m.list.ResetFilter()
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// removeLocalCard drops a card from the local copy of the space.
+func (m *model) removeLocalCard(cardID string) {
+	for i, card := range m.selectedSpace.Cards {
+		if card.ID == cardID {
+			m.selectedSpace.Cards = append(m.selectedSpace.Cards[:i:i], m.selectedSpace.Cards[i+1:]...)
+			break
+		}
+	}
+	delete(m.selected, cardID)
+}
+
+// toggleComment turns the selected card into a comment card or back.
+func (m *model) toggleComment() tea.Cmd {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return nil
+	}
+	card.IsComment = !card.IsComment
+	m.recordUpdate("comment toggle", card)
+	m.replaceCards(card)
+	return updateCard(map[string]interface{}{"id": card.ID, "isComment": card.IsComment})
+}
+
+// commentFilter hides comment cards when hideComments is set.
+func (m *model) commentFilter(cards []Card) []Card {
+	if !m.hideComments {
+		return cards
+	}
+	var filtered []Card
+	for _, card := range cards {
+		if !card.IsComment {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered
+}
+
+const idAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+
+// newID generates a random 21 character ID in the same format Kinopio's
+// clients use, so new items can be referenced before the API responds.
+func newID() string {
+	b := make([]byte, 21)
+	rand.Read(b)
+	for i := range b {
+		b[i] = idAlphabet[b[i]&63]
+	}
+	return string(b)
+}
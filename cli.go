@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// runCommand runs a non-interactive subcommand such as `import`. It
+// reports false when args don't start with a subcommand, in which case
+// the TUI should start instead.
+func runCommand(args []string) (bool, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return false, nil
+	}
+	switch args[0] {
+	case "add":
+		return true, runAdd(args[1:])
+	case "spaces":
+		return true, runSpaces(args[1:])
+	case "cards":
+		return true, runCards(args[1:])
+	case "export":
+		return true, runExport(args[1:])
+	case "backup":
+		return true, runBackup(args[1:])
+	case "restore":
+		return true, runRestore(args[1:])
+	case "import":
+		return true, runImport(args[1:])
+	case "journal":
+		return true, runJournal(args[1:])
+	case "daemon":
+		return true, runDaemon(args[1:])
+	case "watch-clipboard":
+		return true, runWatchClipboard(args[1:])
+	default:
+		return true, fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments, and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// findSpace finds one of the user's spaces by ID or by name,
+// case-insensitively.
+func findSpace(query string) (Space, error) {
+	spaces, err := api.Spaces(context.Background())
+	if err != nil {
+		return Space{}, err
+	}
+	for _, space := range spaces {
+		if space.ID == query {
+			return space, nil
+		}
+	}
+	for _, space := range spaces {
+		if strings.EqualFold(space.Name, query) {
+			return space, nil
+		}
+	}
+	return Space{}, fmt.Errorf("no space named %q", query)
+}
+
+// spaceURL is the web app URL of a space.
+func spaceURL(space Space) string {
+	return "https://kinopio.club/" + space.Url
+}
+
+func runAdd(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	spaceName := fs.String("space", "Inbox", "space to add the card to")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(strings.Join(positional, " "))
+	if name == "" && stdinIsPiped() {
+		return addLines(*spaceName, os.Stdin)
+	}
+	if name == "" {
+		return fmt.Errorf("usage: kinopio-tui add <text> [--space <name or id>]")
+	}
+
+	space, card, err := addCard(*spaceName, name)
+	if err != nil {
+		return err
+	}
+	fmt.Println(cardURL(space, card))
+	return nil
+}
+
+// addLines creates a card for each non-blank line read from r.
+func addLines(spaceName string, r io.Reader) error {
+	var names []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			names = append(names, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stdin: %v", err)
+	}
+	if len(names) == 0 {
+		fmt.Println("Nothing to add")
+		return nil
+	}
+
+	space, cards, err := addCards(spaceName, names)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Added %s to %s (%s)\n", plural(len(cards), "card"), space.Name, spaceURL(space))
+	return nil
+}
+
+// stdinIsPiped reports whether stdin is a pipe or file rather than a
+// terminal.
+func stdinIsPiped() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+func runImport(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: kinopio-tui import <file> | github <owner/repo> [--space <name or id>]")
+	}
+	switch args[0] {
+	case "github":
+		return runImportGitHub(args[1:])
+	default:
+		return runImportFile(args)
+	}
+}
+
+func runImportGitHub(args []string) error {
+	fs := flag.NewFlagSet("import github", flag.ContinueOnError)
+	spaceName := fs.String("space", "", "space to import into (default: a new space named after the repo)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || !strings.Contains(positional[0], "/") {
+		return fmt.Errorf("usage: kinopio-tui import github <owner/repo> [--space <name or id>]")
+	}
+	repo := positional[0]
+
+	issues, err := fetchGitHubIssues(repo)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Printf("%s has no open issues\n", repo)
+		return nil
+	}
+
+	var space Space
+	if *spaceName != "" {
+		space, err = findSpace(*spaceName)
+	} else {
+		space, err = api.CreateSpace(context.Background(), newID(), repo+" issues")
+	}
+	if err != nil {
+		return err
+	}
+
+	boxes, cards := layoutColumns(issueColumns(issues))
+	if err := postBoxes(context.Background(), space.ID, boxes); err != nil {
+		return err
+	}
+	if err := api.CreateCards(context.Background(), space.ID, cards); err != nil {
+		return err
+	}
+	fmt.Printf("Imported %s into %s (%s)\n", plural(len(cards), "issue"), space.Name, spaceURL(space))
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+)
+
+// copiedURL returns the clipboard text if it is a single web URL.
+func copiedURL(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if strings.ContainsAny(text, " \n\t") {
+		return "", false
+	}
+	u, err := url.Parse(text)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return text, true
+}
+
+// runWatchClipboard polls the clipboard and offers to create a link card
+// for each URL that gets copied, or creates them right away with --auto.
+func runWatchClipboard(args []string) error {
+	fs := flag.NewFlagSet("watch-clipboard", flag.ContinueOnError)
+	space := fs.String("space", "Reading", "space to add link cards to")
+	auto := fs.Bool("auto", false, "add copied URLs without asking")
+	interval := fs.Duration("interval", time.Second, "how often to check the clipboard")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if clipboard.Unsupported {
+		return fmt.Errorf("the clipboard isn't supported on this system")
+	}
+
+	// Only URLs copied from now on count.
+	last, _ := clipboard.ReadAll()
+	fmt.Printf("Watching the clipboard for URLs to add to %s (ctrl+c to stop)\n", *space)
+
+	answers := bufio.NewScanner(os.Stdin)
+	for range time.Tick(*interval) {
+		text, err := clipboard.ReadAll()
+		if err != nil || text == last {
+			continue
+		}
+		last = text
+		link, ok := copiedURL(text)
+		if !ok {
+			continue
+		}
+
+		if !*auto {
+			fmt.Printf("Add %s to %s? [y/N] ", link, *space)
+			if !answers.Scan() {
+				return answers.Err()
+			}
+			if answer := strings.ToLower(strings.TrimSpace(answers.Text())); answer != "y" && answer != "yes" {
+				continue
+			}
+		}
+
+		saved, card, err := addCard(*space, link)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			continue
+		}
+		fmt.Printf("Added to %s: %s\n", saved.Name, cardURL(saved, card))
+	}
+	return nil
+}
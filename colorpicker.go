@@ -0,0 +1,154 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// defaultCardColor is the color Kinopio gives cards that don't have one.
+const defaultCardColor = "#e3e3e3"
+
+// pickerColumns is how many colors the color picker puts in a row.
+const pickerColumns = 6
+
+// cardColor is a color offered by the color picker.
+type cardColor struct {
+	name string
+	hex  string // Empty for the default color
+}
+
+// cardColors are the standard card colors the picker offers.
+var cardColors = []cardColor{
+	{"Default", ""},
+	{"Red", "#ffadad"},
+	{"Orange", "#ffd6a5"},
+	{"Yellow", "#fdffb6"},
+	{"Green", "#caffbf"},
+	{"Teal", "#9bf6ff"},
+	{"Blue", "#a0c4ff"},
+	{"Purple", "#bdb2ff"},
+	{"Pink", "#ffc6ff"},
+	{"Gray", "#c4c4c4"},
+	{"White", "#ffffff"},
+	{"Black", "#333333"},
+}
+
+// swatch renders a small block of a card's color, or nothing for cards
+// with the default color and on terminals that can't show color.
+func swatch(color string) string {
+	if color == "" || lipgloss.ColorProfile() == termenv.Ascii {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("■")
+}
+
+// colorPicker is an overlay for choosing a card color from cardColors, or
+// typing one in.
+type colorPicker struct {
+	cursor int
+	onPick func(color string) tea.Cmd
+}
+
+// pickColor opens the color picker for cards, starting on their color,
+// and gives them the color picked.
+func (m *model) pickColor(cards []Card) {
+	if len(cards) == 0 {
+		return
+	}
+	p := &colorPicker{onPick: func(color string) tea.Cmd { return m.setCardColor(cards, color) }}
+	for i, c := range cardColors {
+		if strings.EqualFold(c.hex, cards[0].BackgroundColor) {
+			p.cursor = i
+		}
+	}
+	m.colorPicker = p
+}
+
+// setCardColor gives cards a background color. An empty color resets them
+// to the default.
+func (m *model) setCardColor(cards []Card, color string) tea.Cmd {
+	fields := make([]map[string]interface{}, len(cards))
+	for i := range cards {
+		cards[i].BackgroundColor = color
+		fields[i] = map[string]interface{}{"id": cards[i].ID, "backgroundColor": color}
+	}
+	m.recordUpdate("color change", cards...)
+	m.replaceCards(cards...)
+	return updateCards(fields)
+}
+
+// updateColorPicker moves around the colors with the arrow keys, picks
+// one with enter, and opens a prompt for any other color with #.
+func (m *model) updateColorPicker(msg tea.KeyMsg) tea.Cmd {
+	p := m.colorPicker
+	switch msg.String() {
+	case "esc", "q":
+		m.colorPicker = nil
+	case "left", "h":
+		p.cursor = max(p.cursor-1, 0)
+	case "right", "l":
+		p.cursor = min(p.cursor+1, len(cardColors)-1)
+	case "up", "k":
+		if p.cursor >= pickerColumns {
+			p.cursor -= pickerColumns
+		}
+	case "down", "j":
+		if p.cursor+pickerColumns < len(cardColors) {
+			p.cursor += pickerColumns
+		}
+	case "enter":
+		m.colorPicker = nil
+		return p.onPick(cardColors[p.cursor].hex)
+	case "#":
+		m.colorPicker = nil
+		return m.openPrompt("Background color", "#", func(color string) tea.Cmd {
+			color = strings.TrimSpace(color)
+			if color == "" || color == "#" {
+				return nil
+			}
+			return p.onPick(color)
+		})
+	}
+	return nil
+}
+
+func (p *colorPicker) View() string {
+	cell := lipgloss.NewStyle().Padding(0, 1)
+	var rows []string
+	for start := 0; start < len(cardColors); start += pickerColumns {
+		var cells []string
+		for i := start; i < min(start+pickerColumns, len(cardColors)); i++ {
+			hex := cardColors[i].hex
+			if hex == "" {
+				hex = defaultCardColor
+			}
+			block := lipgloss.NewStyle().Background(lipgloss.Color(hex)).Render("    ")
+			style := cell.BorderStyle(lipgloss.RoundedBorder()).BorderForeground(theme.Border)
+			if i == p.cursor {
+				style = style.BorderForeground(theme.Accent)
+			}
+			cells = append(cells, style.Render(block))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	selected := cardColors[p.cursor]
+	label := selected.name
+	if selected.hex != "" {
+		label += " " + selected.hex
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Render(strings.Join([]string{
+			lipgloss.NewStyle().Bold(true).Render("Card color"),
+			"",
+			strings.Join(rows, "\n"),
+			"",
+			label,
+			dimStyle().Render("enter to pick · # for another color · esc to cancel"),
+		}, "\n"))
+}
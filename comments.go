@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commentGap is the horizontal gap, in canvas pixels, between a card and
+// the comments added to it.
+const commentGap = 40
+
+// defaultCardWidth is the width, in canvas pixels, taken for cards that
+// don't say how wide they are.
+const defaultCardWidth = 200
+
+// The connection type made for comments in spaces that have none to use.
+const (
+	commentTypeName  = "Comment"
+	commentTypeColor = "#c9b6f2"
+)
+
+// cardComments returns the comment cards connected to a card, in reading
+// order.
+func (m *model) cardComments(card Card) []Card {
+	var comments []Card
+	for _, conn := range m.selectedSpace.Connections {
+		otherID := conn.EndItemID
+		switch card.ID {
+		case conn.StartItemID:
+		case conn.EndItemID:
+			otherID = conn.StartItemID
+		default:
+			continue
+		}
+		if other, ok := m.cardByID(otherID); ok && other.IsComment {
+			comments = append(comments, other)
+		}
+	}
+	return sortCards(comments, sortReading)
+}
+
+// cardCommentRows lists a card's comments for the cardDetails table, each
+// after its author.
+func (m *model) cardCommentRows(card Card) []table.Row {
+	var rows []table.Row
+	for _, comment := range m.cardComments(card) {
+		author := m.userName(comment.UserID)
+		if author == "" {
+			author = "comment"
+		}
+		rows = append(rows, table.Row{author, firstLine(comment.Name)})
+	}
+	return rows
+}
+
+// addComment prompts for a comment on the open card, then adds it as a
+// comment card beside the card, below any comments it has, connected to
+// it.
+func (m *model) addComment() tea.Cmd {
+	card := m.selectedCard
+	return m.openPrompt("Comment", "", func(text string) tea.Cmd {
+		if strings.TrimSpace(text) == "" {
+			return nil
+		}
+		x, y := card.X+max(card.Width, defaultCardWidth)+commentGap, card.Y
+		for _, c := range m.cardComments(card) {
+			y = max(y, c.Y+bulkAddSpacing)
+		}
+		comment := Card{ID: newID(), Name: text, X: x, Y: y, IsComment: true, UserID: m.accountID}
+
+		spaceID := m.selectedSpace.ID
+		var newType *ConnectionType
+		typeID := ""
+		if len(m.selectedSpace.ConnectionTypes) > 0 {
+			typeID = m.selectedSpace.ConnectionTypes[0].ID
+		} else {
+			newType = &ConnectionType{ID: newID(), Name: commentTypeName, Color: commentTypeColor}
+			typeID = newType.ID
+			m.selectedSpace.ConnectionTypes = append(m.selectedSpace.ConnectionTypes, *newType)
+		}
+		conn := Connection{ID: newID(), ConnectionTypeID: typeID, StartItemID: comment.ID, EndItemID: card.ID}
+
+		m.selectedSpace.Cards = append(m.selectedSpace.Cards, comment)
+		m.selectedSpace.Connections = append(m.selectedSpace.Connections, conn)
+		m.recordCreate("comment", comment)
+		m.refreshSpaceView()
+		// The connection can only be made once the card is saved, so the
+		// requests go one after another.
+		save := apiCmd(func(ctx context.Context) error {
+			if _, err := api.CreateCard(ctx, spaceID, comment); err != nil {
+				return err
+			}
+			if newType != nil {
+				if err := api.CreateConnectionType(ctx, spaceID, *newType); err != nil {
+					return err
+				}
+			}
+			return api.CreateConnection(ctx, spaceID, conn)
+		})
+		return tea.Batch(save, m.toast("Comment added"))
+	})
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// config is read from config.toml in the user's config directory, e.g.
+// ~/.config/kinopio-tui/config.toml. Environment variables and flags
+// override it, and it's applied once they have.
+type config struct {
+	APIKey        string        `toml:"api_key"`
+	Auth          string        `toml:"auth"` // Where the API key is kept: config or keyring
+	BaseURL       string        `toml:"base_url"`
+	DefaultSpace  string        `toml:"default_space"`  // Opened at startup, by name or ID
+	Theme         string        `toml:"theme"`          // auto, dark, light or high-contrast
+	Refresh       time.Duration `toml:"refresh"`        // Poll for changes this often, e.g. "30s"
+	Timeout       time.Duration `toml:"timeout"`        // Give up on a request after this long
+	Retries       *int          `toml:"retries"`        // Retry failed requests this many times
+	FavoritesOnly bool          `toml:"favorites_only"` // List only favorite spaces
+	Resume        bool          `toml:"resume"`         // Reopen the last space and card on start
+
+	// Keybindings make extra keys act like built-in ones, e.g.
+	// "ctrl+n" = "n".
+	Keybindings map[string]string `toml:"keybindings"`
+
+	// Colors override the theme's, e.g. accent = "#ff5f87".
+	Colors palette `toml:"colors"`
+}
+
+// userConfig is loaded once at startup, before any command runs.
+var userConfig config
+
+func configPath() (string, error) {
+	if path := os.Getenv("KINOPIO_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kinopio-tui", "config.toml"), nil
+}
+
+// loadConfig reads the config file and applies the environment on top of
+// it. A missing file is an empty config.
+func loadConfig() (config, error) {
+	var cfg config
+	path, err := configPath()
+	if err != nil {
+		return cfg, err
+	}
+	if _, err := toml.DecodeFile(path, &cfg); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, fmt.Errorf("error reading config file %s: %v", path, err)
+	}
+	if key := os.Getenv("KINOPIO_API_KEY"); key != "" {
+		cfg.APIKey = key
+	}
+	if auth := os.Getenv("KINOPIO_AUTH"); auth != "" {
+		cfg.Auth = auth
+	}
+	if url := os.Getenv("KINOPIO_BASE_URL"); url != "" {
+		cfg.BaseURL = url
+	}
+	if theme := os.Getenv("KINOPIO_THEME"); theme != "" {
+		cfg.Theme = theme
+	}
+	return cfg, nil
+}
+
+// apply points the API client at the configured server and key, sets its
+// timeout and retries, and sets the theme.
+func (c config) apply() error {
+	switch c.Auth {
+	case "", authConfig:
+	case authKeyring:
+		if c.APIKey == "" {
+			key, err := keyringAPIKey()
+			if err != nil {
+				return err
+			}
+			c.APIKey = key
+		}
+	default:
+		return fmt.Errorf("unknown auth %q: use config or keyring", c.Auth)
+	}
+	if c.APIKey != "" {
+		api.APIKey = c.APIKey
+	}
+	if c.BaseURL != "" {
+		api.BaseURL = strings.TrimRight(c.BaseURL, "/")
+	}
+	if c.Timeout > 0 {
+		api.Timeout = c.Timeout
+	}
+	if c.Retries != nil {
+		api.Retries = *c.Retries
+	}
+	p, err := themeNamed(c.Theme)
+	if err != nil {
+		return err
+	}
+	setTheme(p.with(c.Colors))
+	return nil
+}
+
+// keyNames maps key names such as "enter" or "ctrl+n" to their key types.
+var keyNames = func() map[string]tea.KeyType {
+	names := map[string]tea.KeyType{}
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if t == tea.KeyRunes {
+			continue
+		}
+		if name := (tea.Key{Type: t}).String(); name != "" {
+			names[name] = t
+		}
+	}
+	return names
+}()
+
+// parseKey turns a key name, as tea.KeyMsg.String() writes it, back into
+// a key press.
+func parseKey(name string) (tea.KeyMsg, bool) {
+	alt := false
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		alt, name = true, rest
+	}
+	if t, ok := keyNames[name]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}, true
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// parseKeybindings checks the configured keybindings, keyed by the key
+// that's pressed.
+func parseKeybindings(bindings map[string]string) (map[string]tea.KeyMsg, error) {
+	keymap := make(map[string]tea.KeyMsg, len(bindings))
+	for from, to := range bindings {
+		if _, ok := parseKey(from); !ok {
+			return nil, fmt.Errorf("unknown key %q in keybindings", from)
+		}
+		key, ok := parseKey(to)
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q in keybindings", to)
+		}
+		keymap[from] = key
+	}
+	return keymap, nil
+}
+
+// remapKey swaps a key press for the key it's bound to, unless something
+// is being typed.
+func (m *model) remapKey(msg tea.Msg) tea.Msg {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || m.prompt != nil || m.bulkAdd != nil || m.switcher != nil || m.search != nil || m.palette != nil || (m.find != nil && m.find.editing) || m.nameInput != nil || m.list.SettingFilter() {
+		return msg
+	}
+	if bound, ok := m.keymap[key.String()]; ok {
+		return bound
+	}
+	return msg
+}
+
+// openStartSpace opens the configured default space once the spaces have
+// loaded, or with --resume, the space and card open when the app last quit.
+func (m *model) openStartSpace() tea.Cmd {
+	if m.resume {
+		if cmd, ok := m.resumeSession(); ok {
+			m.startSpace = ""
+			return cmd
+		}
+	}
+	query := m.startSpace
+	m.startSpace = ""
+	if query == "" {
+		return nil
+	}
+	for _, space := range m.spaces {
+		if space.ID == query || strings.EqualFold(space.Name, query) {
+			return m.openSpace(space)
+		}
+	}
+	return func() tea.Msg { return fmt.Errorf("default space %q not found", query) }
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// confirmDialog is a modal yes/no prompt. Anything that deletes or
+// overwrites data opens one through askConfirm instead of acting right
+// away, so every risky operation is guarded the same way.
+type confirmDialog struct {
+	message     string
+	destructive bool
+	yes         bool // whether "Yes" is the focused button
+	onConfirm   func() tea.Cmd
+}
+
+func newConfirmDialog(message string, destructive bool, onConfirm func() tea.Cmd) *confirmDialog {
+	return &confirmDialog{
+		message:     message,
+		destructive: destructive,
+		yes:         !destructive, // Destructive prompts default to "No"
+		onConfirm:   onConfirm,
+	}
+}
+
+// Update handles a key press and reports whether the dialog is finished.
+func (d *confirmDialog) Update(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "left", "right", "h", "l", "tab", "shift+tab":
+		d.yes = !d.yes
+	case "y", "Y":
+		return true, d.onConfirm()
+	case "n", "N", "esc":
+		return true, nil
+	case "enter":
+		if d.yes {
+			return true, d.onConfirm()
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (d *confirmDialog) View() string {
+	accent := theme.Accent
+	if d.destructive {
+		accent = theme.Danger
+	}
+
+	button := lipgloss.NewStyle().Padding(0, 2).Margin(0, 1)
+	active := button.Foreground(theme.OnAccent).Background(accent)
+	inactive := button.Foreground(theme.BarText).Background(theme.Bar)
+
+	yes, no := inactive.Render("Yes"), active.Render("No")
+	if d.yes {
+		yes, no = active.Render("Yes"), inactive.Render("No")
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Width(50).Align(lipgloss.Center).Render(d.message),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, yes, no),
+		"",
+		dimStyle().Render("y/n to answer, ←/→ and enter to choose"),
+	)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Padding(1, 2).
+		Render(body)
+}
+
+// askConfirm opens a confirmation dialog; onConfirm runs only if the user
+// answers yes.
+func (m *model) askConfirm(message string, destructive bool, onConfirm func() tea.Cmd) {
+	m.confirm = newConfirmDialog(message, destructive, onConfirm)
+}
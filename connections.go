@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func (m *model) showConnections() {
+	m.currentView = "connections"
+	m.list.Title = m.selectedSpace.Name + " → Connections"
+	items := make([]list.Item, len(m.selectedSpace.Connections))
+	for i, conn := range m.selectedSpace.Connections {
+		items[i] = m.connectionItem(conn)
+	}
+	m.setItems(items)
+}
+
+func (m *model) connectionItem(conn Connection) connectionListItem {
+	item := connectionListItem{Connection: conn, from: "(unknown card)", to: "(unknown card)"}
+	if card, ok := m.cardByID(conn.StartItemID); ok {
+		item.from = firstLine(card.Name)
+	}
+	if card, ok := m.cardByID(conn.EndItemID); ok {
+		item.to = firstLine(card.Name)
+	}
+	if connType, ok := m.connectionType(conn.ConnectionTypeID); ok {
+		item.typeName = connType.Name
+	}
+	return item
+}
+
+func (m *model) connectionType(id string) (ConnectionType, bool) {
+	for _, connType := range m.selectedSpace.ConnectionTypes {
+		if connType.ID == id {
+			return connType, true
+		}
+	}
+	return ConnectionType{}, false
+}
+
+// editConnectionLabel prompts for a new label for the selected connection,
+// updates it locally and saves it to the API.
+func (m *model) editConnectionLabel() tea.Cmd {
+	item, ok := m.list.SelectedItem().(connectionListItem)
+	if !ok {
+		return nil
+	}
+	id := item.Connection.ID
+	return m.openPrompt("Label", item.Connection.Label, func(label string) tea.Cmd {
+		for i, conn := range m.selectedSpace.Connections {
+			if conn.ID == id {
+				m.selectedSpace.Connections[i].Label = label
+			}
+		}
+		m.showConnections()
+		return updateConnection(map[string]interface{}{"id": id, "label": label})
+	})
+}
+
+// cardConnectionRows lists the connections touching a card for the
+// cardDetails table: the card at the other end, and the connection's type
+// in its color.
+func (m *model) cardConnectionRows(card Card) []table.Row {
+	var rows []table.Row
+	for _, conn := range m.selectedSpace.Connections {
+		direction, otherID := "→ to", conn.EndItemID
+		switch card.ID {
+		case conn.StartItemID:
+		case conn.EndItemID:
+			direction, otherID = "← from", conn.StartItemID
+		default:
+			continue
+		}
+
+		other := "(unknown card)"
+		if c, ok := m.cardByID(otherID); ok {
+			other = firstLine(c.Name)
+		}
+		if conn.Label != "" {
+			other += fmt.Sprintf(" (%q)", conn.Label)
+		}
+		typeName, color := "connection", ""
+		if connType, ok := m.connectionType(conn.ConnectionTypeID); ok {
+			typeName, color = connType.Name, connType.Color
+		}
+		// The table counts color codes when truncating cells, so leave
+		// room for them.
+		value := ansi.Truncate(typeName+": "+other, connectionCellWidth, "…")
+		if color != "" {
+			value = lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("●") + " " + value
+		}
+		rows = append(rows, table.Row{direction, value})
+	}
+	return rows
+}
+
+// connectionCellWidth is how much of a connection's description fits in
+// the cardDetails value column alongside its styled type marker.
+const connectionCellWidth = 40
+
+type connectionListItem struct {
+	Connection Connection
+	from, to   string
+	typeName   string
+}
+
+func (i connectionListItem) FilterValue() string {
+	return i.from + " " + i.to + " " + i.Connection.Label
+}
+func (i connectionListItem) Title() string { return i.from + " → " + i.to }
+func (i connectionListItem) Description() string {
+	label := "no label"
+	if i.Connection.Label != "" {
+		label = fmt.Sprintf("%q", i.Connection.Label)
+	}
+	if i.typeName != "" {
+		return label + " · " + i.typeName
+	}
+	return label
+}
+
+// firstLine returns the first line of a card name, for places that only
+// have room for one.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
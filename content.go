@@ -0,0 +1,39 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	tagPattern = regexp.MustCompile(`\[\[([^\[\]]+)\]\]`)
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"'\)\]]+`)
+)
+
+// cardTags returns the [[tag]] names in a card's text, without duplicates.
+func cardTags(name string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, match := range tagPattern.FindAllStringSubmatch(name, -1) {
+		tag := strings.TrimSpace(match[1])
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// cardURLs returns the URLs in a card's text, without duplicates.
+func cardURLs(name string) []string {
+	var urls []string
+	seen := map[string]bool{}
+	for _, url := range urlPattern.FindAllString(name, -1) {
+		url = strings.TrimRight(url, ".,;:!?")
+		if !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// writeClipboard puts text on the system clipboard. Over SSH, or where
+// there's no clipboard tool, it asks the terminal to do it with an OSC 52
+// escape sequence instead.
+func writeClipboard(text string) {
+	if os.Getenv("SSH_TTY") == "" && os.Getenv("SSH_CONNECTION") == "" && !clipboard.Unsupported {
+		if err := clipboard.WriteAll(text); err == nil {
+			return
+		}
+	}
+	termenv.Copy(text)
+}
+
+// copyToClipboard copies text and says what was copied in a toast.
+func (m *model) copyToClipboard(what, text string) tea.Cmd {
+	writeClipboard(text)
+	return m.toast("Copied " + what)
+}
+
+// startCopy copies a space's URL, or on a card, waits for a second key to
+// say what to copy.
+func (m *model) startCopy() tea.Cmd {
+	if _, ok := m.selectedListCard(); ok && (m.currentView == "cards" || m.currentView == "cardDetails") {
+		m.copying = true
+		return nil
+	}
+	if m.currentView == "list" {
+		if item, ok := m.list.SelectedItem().(listItem); ok {
+			return m.copyToClipboard("the space's URL", spaceURL(item.Space))
+		}
+		return nil
+	}
+	if m.inSpace() {
+		return m.copyToClipboard("the space's URL", spaceURL(m.selectedSpace))
+	}
+	return nil
+}
+
+// updateCopy copies the card's text on y, its link on l, or its space's
+// URL on s. Any other key cancels.
+func (m *model) updateCopy(msg tea.KeyMsg) tea.Cmd {
+	m.copying = false
+	switch msg.String() {
+	case "y":
+		return m.copyCardText()
+	case "l":
+		return m.copyCardLink()
+	case "s":
+		return m.copyToClipboard("the space's URL", spaceURL(m.selectedSpace))
+	}
+	return nil
+}
+
+func (m *model) copyCardText() tea.Cmd {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return nil
+	}
+	return m.copyToClipboard("the card's text", card.Name)
+}
+
+func (m *model) copyCardLink() tea.Cmd {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return nil
+	}
+	return m.copyToClipboard("the card's link", cardURL(m.selectedSpace, card))
+}
+
+// copyHelp is the help line while waiting for what to copy.
+const copyHelp = "\nCopy: y the card's text · l its link · s the space's URL · any other key to cancel"
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// maxWebhookBody limits how much of a webhook body is read.
+const maxWebhookBody = 1 << 20
+
+// webhookCard is the JSON form of a webhook payload. Plain-text bodies
+// are used as the card name directly.
+type webhookCard struct {
+	Name  string `json:"name"`
+	Space string `json:"space"`
+}
+
+type webhookServer struct {
+	token      string
+	inboxSpace string
+}
+
+func runDaemon(args []string) error {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:7420", "address to listen on")
+	token := fs.String("token", os.Getenv("KINOPIO_DAEMON_TOKEN"), "shared secret webhooks must send as a bearer token")
+	inbox := fs.String("inbox", "Inbox", "space that /inbox webhooks add cards to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s := &webhookServer{token: *token, inboxSpace: *inbox}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /cards", s.handleCard(""))
+	mux.HandleFunc("POST /inbox", s.handleCard(*inbox))
+
+	// Without a token, any local process or web page could create cards,
+	// so make one up for this run.
+	if s.token == "" {
+		s.token = newID()
+		log.Printf("No --token set, webhooks must send: Authorization: Bearer %s", s.token)
+	}
+	log.Printf("Listening for webhooks on http://%s", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// handleCard creates a card from a webhook. The space comes from
+// defaultSpace, or else the ?space= parameter or the payload's "space".
+func (s *webhookServer) handleCard(defaultSpace string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Browsers send an Origin with cross-site requests; webhooks
+		// don't, so a web page can't post cards even with the token.
+		if r.Header.Get("Origin") != "" {
+			http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+			return
+		}
+		want := []byte("Bearer " + s.token)
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		payload := webhookCard{Name: string(body)}
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			payload = webhookCard{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				http.Error(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		payload.Name = strings.TrimSpace(payload.Name)
+
+		spaceQuery := defaultSpace
+		if spaceQuery == "" {
+			spaceQuery = r.URL.Query().Get("space")
+		}
+		if spaceQuery == "" {
+			spaceQuery = payload.Space
+		}
+		if payload.Name == "" || spaceQuery == "" {
+			http.Error(w, "a card name and space are required", http.StatusBadRequest)
+			return
+		}
+
+		space, card, err := addCard(spaceQuery, payload.Name)
+		if err != nil {
+			log.Printf("Error creating card in %q: %v", spaceQuery, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		log.Printf("Created card %q in %s", firstLine(card.Name), space.Name)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{ //nolint: errcheck
+			"id":    card.ID,
+			"space": space.Name,
+			"url":   cardURL(space, card),
+		})
+	}
+}
+
+// addCard creates a card below the existing cards in the named space.
+func addCard(spaceQuery, name string) (Space, Card, error) {
+	space, cards, err := addCards(spaceQuery, []string{name})
+	if err != nil {
+		return Space{}, Card{}, err
+	}
+	return space, cards[0], nil
+}
+
+// addCards creates a stack of cards below the existing cards in the named
+// space, in a single request.
+func addCards(spaceQuery string, names []string) (Space, []Card, error) {
+	found, err := findSpace(spaceQuery)
+	if err != nil {
+		return Space{}, nil, err
+	}
+	space, err := api.Space(context.Background(), found.ID)
+	if err != nil {
+		return Space{}, nil, err
+	}
+	x, y := nextCardPosition(space.Cards)
+	cards := make([]Card, len(names))
+	for i, name := range names {
+		cards[i] = Card{ID: newID(), Name: name, X: x, Y: y + i*bulkAddSpacing}
+	}
+	if err := api.CreateCards(context.Background(), space.ID, cards); err != nil {
+		return Space{}, nil, err
+	}
+	return space, cards, nil
+}
+
+// cardURL links to a card in the web app.
+func cardURL(space Space, card Card) string {
+	return fmt.Sprintf("%s?card=%s", spaceURL(space), card.ID)
+}
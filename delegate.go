@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// prefixedItem is implemented by list items that show something (such as
+// a card number) in front of their title. The prefix is kept out of the
+// title so filter matches still line up with the item's FilterValue.
+type prefixedItem interface {
+	Prefix() string
+}
+
+// dimmedItem is implemented by list items that can be de-emphasized, such
+// as comment cards.
+type dimmedItem interface {
+	Dimmed() bool
+}
+
+// swatchedItem is implemented by list items that have a color, such as
+// cards, shown as a swatch before their title.
+type swatchedItem interface {
+	Swatch() string
+}
+
+// highlightedItem is implemented by list items that show matches found
+// outside the list's own filter, such as the card find.
+type highlightedItem interface {
+	Highlights() []int
+}
+
+// itemDelegate renders items the same way list.DefaultDelegate does, with
+// support for the optional item interfaces above.
+type itemDelegate struct {
+	list.DefaultDelegate
+}
+
+func newItemDelegate() itemDelegate {
+	d := list.NewDefaultDelegate()
+	d.Styles.NormalTitle = d.Styles.NormalTitle.Foreground(theme.Text)
+	d.Styles.NormalDesc = d.Styles.NormalDesc.Foreground(theme.Dim)
+	d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(theme.Title).BorderForeground(theme.Title)
+	d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(theme.Title).BorderForeground(theme.Title)
+	d.Styles.DimmedTitle = d.Styles.DimmedTitle.Foreground(theme.Dim)
+	d.Styles.DimmedDesc = d.Styles.DimmedDesc.Foreground(theme.Dim)
+	d.Styles.FilterMatch = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.OnMatch).
+		Background(theme.Match)
+	return itemDelegate{d}
+}
+
+func (d itemDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i, ok := item.(list.DefaultItem)
+	if !ok || m.Width() <= 0 {
+		return
+	}
+	if section, ok := item.(sectionItem); ok {
+		fmt.Fprint(w, sectionStyle.Render(ansi.Truncate(section.title, m.Width()-2, "…"))) //nolint: errcheck
+		return
+	}
+	s := &d.Styles
+
+	var (
+		prefix   string
+		color    string
+		isDimmed bool
+	)
+	if p, ok := item.(prefixedItem); ok {
+		prefix = p.Prefix()
+	}
+	if sw, ok := item.(swatchedItem); ok {
+		color = swatch(sw.Swatch())
+	}
+	if di, ok := item.(dimmedItem); ok {
+		isDimmed = di.Dimmed()
+	}
+
+	// Prevent text from exceeding list width
+	textwidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
+	if color != "" {
+		textwidth -= 2
+	}
+	title := ansi.Truncate(i.Title(), textwidth-lipgloss.Width(prefix), "…")
+	var descLines []string
+	for n, line := range strings.Split(i.Description(), "\n") {
+		if n >= d.Height()-1 {
+			break
+		}
+		descLines = append(descLines, ansi.Truncate(line, textwidth, "…"))
+	}
+	desc := strings.Join(descLines, "\n")
+
+	var (
+		isSelected  = index == m.Index()
+		emptyFilter = m.FilterState() == list.Filtering && m.FilterValue() == ""
+		isFiltered  = m.FilterState() == list.Filtering || m.FilterState() == list.FilterApplied
+	)
+
+	// Match positions index into FilterValue, so they can only be
+	// highlighted in titles that are the filter value.
+	var matchedRunes []int
+	if isFiltered && index < len(m.VisibleItems()) && i.Title() == item.FilterValue() {
+		matchedRunes = m.MatchesForItem(index)
+	}
+
+	titleStyle, descStyle := s.NormalTitle, s.NormalDesc
+	switch {
+	case emptyFilter:
+		titleStyle, descStyle = s.DimmedTitle, s.DimmedDesc
+	case isSelected && m.FilterState() != list.Filtering:
+		titleStyle, descStyle = s.SelectedTitle, s.SelectedDesc
+	case isDimmed:
+		titleStyle, descStyle = s.DimmedTitle, s.DimmedDesc
+	}
+
+	if h, ok := item.(highlightedItem); ok && !isFiltered {
+		matchedRunes = h.Highlights()
+	}
+
+	if len(matchedRunes) > 0 && !emptyFilter {
+		unmatched := titleStyle.Inline(true)
+		matched := unmatched.Inherit(s.FilterMatch)
+		title = lipgloss.StyleRunes(title, matchedRunes, matched, unmatched)
+	}
+	if color != "" {
+		// Style the rest of the title piece by piece, as the swatch's
+		// color codes end the title style's.
+		unmatched := titleStyle.Inline(true)
+		if len(matchedRunes) == 0 || emptyFilter {
+			title = unmatched.Render(title)
+		}
+		title = titleStyle.Render(color + unmatched.Render(" "+prefix) + title)
+	} else {
+		title = titleStyle.Render(prefix + title)
+	}
+	desc = descStyle.Render(desc)
+
+	if d.ShowDescription {
+		fmt.Fprintf(w, "%s\n%s", title, desc) //nolint: errcheck
+		return
+	}
+	fmt.Fprintf(w, "%s", title) //nolint: errcheck
+}
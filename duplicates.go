@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// duplicateThreshold is the similarity (0–1) above which two cards are
+// reported as near-duplicates.
+const duplicateThreshold = 0.85
+
+// duplicatePair is two cards with identical or near-identical text. keep
+// is the older of the two, and is the one merging keeps.
+type duplicatePair struct {
+	keep, remove Card
+	similarity   float64
+}
+
+// normalizeText lowercases text and reduces it to words, so that
+// differences in punctuation and spacing don't hide duplicates.
+func normalizeText(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+func bigrams(s string) map[string]int {
+	runes := []rune(s)
+	grams := map[string]int{}
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
+
+// similarity is the Dice coefficient of two bigram sets.
+func similarity(a, b map[string]int) float64 {
+	total := 0
+	for _, n := range a {
+		total += n
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	shared := 0
+	for gram, n := range a {
+		shared += min(n, b[gram])
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+// findDuplicates pairs up cards whose normalized text is identical or
+// similar enough, most similar first.
+func findDuplicates(cards []Card) []duplicatePair {
+	type entry struct {
+		card  Card
+		text  string
+		grams map[string]int
+	}
+	var entries []entry
+	for _, card := range cards {
+		text := normalizeText(card.Name)
+		if text != "" {
+			entries = append(entries, entry{card, text, bigrams(text)})
+		}
+	}
+
+	var pairs []duplicatePair
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			a, b := entries[i], entries[j]
+			score := 1.0
+			if a.text != b.text {
+				score = similarity(a.grams, b.grams)
+			}
+			if score < duplicateThreshold {
+				continue
+			}
+			keep, remove := a.card, b.card
+			if remove.CreatedAt.Before(keep.CreatedAt) && !remove.CreatedAt.IsZero() {
+				keep, remove = remove, keep
+			}
+			pairs = append(pairs, duplicatePair{keep, remove, score})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].similarity > pairs[j].similarity })
+	return pairs
+}
+
+func (m *model) showDuplicates() {
+	m.currentView = "duplicates"
+	pairs := findDuplicates(m.selectedSpace.Cards)
+	m.list.Title = fmt.Sprintf("%s → Duplicates (%d)", m.selectedSpace.Name, len(pairs))
+	items := make([]list.Item, len(pairs))
+	for i, pair := range pairs {
+		items[i] = duplicateListItem{pair}
+	}
+	m.setItems(items)
+}
+
+// deleteDuplicate removes the newer card of the highlighted pair, after
+// confirmation.
+func (m *model) deleteDuplicate() {
+	item, ok := m.list.SelectedItem().(duplicateListItem)
+	if !ok {
+		return
+	}
+	card := item.pair.remove
+	m.askConfirm(fmt.Sprintf("Delete %q?", firstLine(card.Name)), true, func() tea.Cmd {
+		m.recordRemove("duplicate deletion", card)
+		m.removeLocalCard(card.ID)
+		m.showDuplicates()
+		return removeCard(card.ID)
+	})
+}
+
+// mergeDuplicate keeps the older card of the highlighted pair, appending
+// the newer card's text if it differs, and deletes the newer card.
+func (m *model) mergeDuplicate() {
+	item, ok := m.list.SelectedItem().(duplicateListItem)
+	if !ok {
+		return
+	}
+	keep, remove := item.pair.keep, item.pair.remove
+	message := fmt.Sprintf("Merge %q into %q?", firstLine(remove.Name), firstLine(keep.Name))
+	m.askConfirm(message, true, func() tea.Cmd {
+		var cmds []tea.Cmd
+		changes := []cardChange{{before: &remove}}
+		if normalizeText(keep.Name) != normalizeText(remove.Name) {
+			before := keep
+			keep.Name += "\n\n" + remove.Name
+			changes = append(changes, cardChange{before: &before, after: &keep})
+			m.replaceCards(keep)
+			cmds = append(cmds, updateCard(map[string]interface{}{"id": keep.ID, "name": keep.Name}))
+		}
+		m.recordEdit("merge", changes)
+		m.removeLocalCard(remove.ID)
+		m.showDuplicates()
+		return tea.Batch(append(cmds, removeCard(remove.ID))...)
+	})
+}
+
+type duplicateListItem struct {
+	pair duplicatePair
+}
+
+func (i duplicateListItem) FilterValue() string { return i.pair.keep.Name }
+func (i duplicateListItem) Title() string       { return firstLine(i.pair.keep.Name) }
+func (i duplicateListItem) Description() string {
+	return fmt.Sprintf("≈ %s (%.0f%% similar)", firstLine(i.pair.remove.Name), i.pair.similarity*100)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// nameRow is the index of the name row in the cardDetails table.
+const nameRow = 1
+
+// editCardName turns the name row in cardDetails into a text input.
+func (m *model) editCardName() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetValue(m.selectedCard.Name)
+	input.Width = 50 // Leaves room in the value column for the cursor's styling
+	input.Focus()
+	m.nameInput = &input
+	m.refreshCardRows()
+	m.cardTable.SetCursor(nameRow)
+	return textinput.Blink
+}
+
+// updateNameInput handles a key press while the name is being edited.
+// Enter saves the new name and esc puts the old one back.
+func (m *model) updateNameInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		name := m.nameInput.Value()
+		m.nameInput = nil
+		if name == m.selectedCard.Name {
+			m.refreshCardRows()
+			return nil
+		}
+		return m.renameCard(m.selectedCard, name)
+	case "esc":
+		m.nameInput = nil
+		m.refreshCardRows()
+		return nil
+	}
+	var cmd tea.Cmd
+	*m.nameInput, cmd = m.nameInput.Update(msg)
+	m.refreshCardRows()
+	return cmd
+}
+
+// renameCard gives a card new text, locally and in Kinopio.
+func (m *model) renameCard(card Card, name string) tea.Cmd {
+	card.Name = name
+	card.NameUpdatedAt = time.Now()
+	m.recordUpdate("name edit", card)
+	m.replaceCards(card)
+	return updateCard(map[string]interface{}{"id": card.ID, "name": card.Name})
+}
+
+// refreshCardRows redraws the cardDetails table's rows in place, keeping
+// the cursor where it is.
+func (m *model) refreshCardRows() {
+	m.cardTable.SetRows(m.cardDetailRows())
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorDoneMsg says the editor has exited. done is given what it left in
+// the file.
+type editorDoneMsg struct {
+	path string // The temp file being edited
+	err  error
+	done func(text string) tea.Cmd
+}
+
+// editorCommand opens path in the user's editor: $VISUAL, then $EDITOR,
+// then vi. The variables may hold arguments too, as in "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// runEditor writes text to a temp file named after pattern (as in
+// os.CreateTemp) and hands the terminal to the user's editor to change it.
+// When the editor exits, editorDone passes the text on to done.
+func runEditor(pattern, text string, done func(text string) tea.Cmd) tea.Cmd {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return func() tea.Msg { return fmt.Errorf("error creating a file to edit: %v", err) }
+	}
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return func() tea.Msg { return fmt.Errorf("error writing %s: %v", f.Name(), err) }
+	}
+	path := f.Name()
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return editorDoneMsg{path: path, err: err, done: done}
+	})
+}
+
+// editorDone reads back the temp file the editor was working on and
+// removes it.
+func (m *model) editorDone(msg editorDoneMsg) tea.Cmd {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		return func() tea.Msg { return fmt.Errorf("error running the editor: %v", msg.err) }
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		return func() tea.Msg { return fmt.Errorf("error reading %s: %v", msg.path, err) }
+	}
+	return msg.done(string(data))
+}
+
+// editInEditor opens the selected card's text in the user's editor and
+// saves it if it changed.
+func (m *model) editInEditor() tea.Cmd {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return nil
+	}
+	return runEditor("kinopio-card-*.md", card.Name, func(text string) tea.Cmd {
+		card, ok := m.cardByID(card.ID)
+		if !ok {
+			return m.toast("The card is gone: your edit wasn't saved")
+		}
+		// Editors end files with a newline the card didn't have.
+		name := strings.TrimRight(text, "\r\n")
+		if name == card.Name {
+			return m.toast("No changes")
+		}
+		return tea.Batch(m.renameCard(card, name), m.toast("Card updated"))
+	})
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
+)
+
+// errorKind sorts errors by what the user can do about them.
+type errorKind int
+
+const (
+	otherError     errorKind = iota
+	authError                // The API key is missing or was rejected
+	forbiddenError           // The API key's user can't see or change it
+	notFoundError            // The space or card doesn't exist, or is private
+	rateLimitError           // Too many requests
+	networkError             // The API couldn't be reached
+)
+
+func classifyError(err error) errorKind {
+	switch {
+	case errors.Is(err, kinopio.ErrNoAPIKey), errors.Is(err, kinopio.ErrUnauthorized):
+		return authError
+	case errors.Is(err, kinopio.ErrForbidden):
+		return forbiddenError
+	case errors.Is(err, kinopio.ErrNotFound):
+		return notFoundError
+	case errors.Is(err, kinopio.ErrRateLimited):
+		return rateLimitError
+	case kinopio.IsUnreachable(err):
+		return networkError
+	}
+	return otherError
+}
+
+// explain says what went wrong in plain words, and what might fix it.
+func (k errorKind) explain() (string, string) {
+	switch k {
+	case authError:
+		return "Kinopio didn't accept your API key.", "Check the key in your config file or KINOPIO_API_KEY."
+	case forbiddenError:
+		return "You don't have access to that.", "It may be private to someone else: ask them to invite you."
+	case notFoundError:
+		return "That couldn't be found.", "It may have been removed, or it's private to someone else."
+	case rateLimitError:
+		return "Kinopio is limiting how many requests can be made.", "Wait a minute, then try again."
+	case networkError:
+		return "Kinopio can't be reached.", "Check your connection, then try again."
+	}
+	return "Something went wrong.", ""
+}
+
+// showError replaces the view with an explanation of err until it's
+// retried or dismissed. If a space was being opened, retrying opens it
+// again. When the API key was rejected, it asks for a new one first.
+func (m *model) showError(err error) tea.Cmd {
+	m.err = err
+	m.retryOpen = nil
+	if m.loading && m.opening.ID != "" {
+		space := m.opening
+		m.retryOpen = &space
+	}
+	m.loading = false
+	m.loadProgress = nil
+	if classifyError(err) == authError {
+		m.reauth = newSetupModel()
+		m.reauth.reauth = true
+		return m.reauth.Init()
+	}
+	return nil
+}
+
+// updateError handles keys on the error view: r retries, b goes back to
+// the view underneath, and q quits.
+func (m *model) updateError(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "r":
+		return m.retryFailed()
+	case "b", "esc":
+		m.err = nil
+		m.retryOpen = nil
+	}
+	return nil
+}
+
+// retryFailed clears the error and tries again: opening the space that
+// failed to open, or fetching what's showing.
+func (m *model) retryFailed() tea.Cmd {
+	m.err = nil
+	if space := m.retryOpen; space != nil {
+		m.retryOpen = nil
+		return m.openSpace(*space)
+	}
+	if len(m.spaces) == 0 {
+		m.loading = true
+		return tea.Batch(fetchSpaces(), m.spinner.Tick)
+	}
+	return m.refresh()
+}
+
+// updateReauth passes keys to the prompt for a new API key. Cancelling it
+// leaves the error showing.
+func (m *model) updateReauth(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "esc" && !m.reauth.checking {
+		m.reauth = nil
+		return nil
+	}
+	_, cmd := m.reauth.Update(msg)
+	return cmd
+}
+
+// reauthenticated switches to the new API key, saves it, and retries what
+// failed with the old one.
+func (m *model) reauthenticated(msg setupDoneMsg) tea.Cmd {
+	m.reauth = nil
+	api.APIKey = msg.apiKey
+	if _, err := saveAPIKey(msg.apiKey); err != nil {
+		m.err = err
+		return nil
+	}
+	return m.retryFailed()
+}
+
+func (m *model) errorView() string {
+	problem, hint := classifyError(m.err).explain()
+	dim := dimStyle()
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(theme.Danger).Render(problem),
+	}
+	if hint != "" {
+		lines = append(lines, hint)
+	}
+	lines = append(lines,
+		"",
+		dim.Width(max(m.width-6, 20)).Render(m.err.Error()),
+		"",
+		dim.Render("r to retry · b to go back · q to quit"),
+	)
+	return lipgloss.NewStyle().Padding(2, 3).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exploreMsg carries the public spaces featured in Explore.
+type exploreMsg struct {
+	spaces []Space
+}
+
+func fetchExplore() tea.Cmd {
+	return func() tea.Msg {
+		spaces, err := api.ExploreSpaces(programCtx)
+		if err != nil {
+			return err
+		}
+		return exploreMsg{spaces: spaces}
+	}
+}
+
+// openExplore shows the public spaces, fetching them the first time.
+func (m *model) openExplore() tea.Cmd {
+	if m.exploreSpaces != nil {
+		m.showExplore()
+		return nil
+	}
+	m.loading = true
+	return tea.Batch(fetchExplore(), m.spinner.Tick)
+}
+
+func (m *model) showExplore() {
+	m.currentView = "explore"
+	m.list.Title = "Explore"
+	items := make([]list.Item, len(m.exploreSpaces))
+	for i, space := range m.exploreSpaces {
+		items[i] = exploreListItem{space}
+	}
+	m.setItems(items)
+}
+
+// openExploreSpace opens a public space for reading.
+func (m *model) openExploreSpace(space Space) tea.Cmd {
+	m.readOnlySpace = space.ID
+	return m.openSpace(space)
+}
+
+// readOnly reports whether the open space is someone else's, opened from
+// Explore, and can't be changed.
+func (m *model) readOnly() bool {
+	return m.readOnlySpace != "" && m.inSpace() && m.selectedSpace.ID == m.readOnlySpace
+}
+
+// editKeys are the keys that change a space, by the views they change it
+// in, which do nothing in read-only spaces. Undo and redo change it from
+// any view.
+var editKeys = map[string][]key.Binding{
+	"cards": {
+		keys.New, keys.Delete, keys.ExternalEdit, keys.EditSpace, keys.Comment, keys.Task,
+		keys.Color, keys.Move, keys.BulkAdd, keys.Archive, keys.Arrange, keys.ImportMarks,
+	},
+	"cardDetails": {
+		keys.New, keys.Edit, keys.ExternalEdit, keys.Comment, keys.Task, keys.Color,
+		keys.Nudge, keys.Move, keys.Archive,
+	},
+	"boxes":       {keys.New, keys.Edit, keys.Resize, keys.Delete},
+	"connections": {keys.Edit},
+	"duplicates":  {keys.Merge, keys.Delete},
+	"bulkActions": {keys.Enter},
+	"kanban":      {keys.MoveLeft, keys.MoveRight},
+}
+
+// editsReadOnly reports whether a key would change the open space in the
+// current view, when the space is read-only.
+func (m *model) editsReadOnly(k string) bool {
+	if !m.readOnly() {
+		return false
+	}
+	// While finding, n and N move between the matches.
+	if m.find != nil && (slices.Contains(keys.NextMatch.Keys(), k) || slices.Contains(keys.PrevMatch.Keys(), k)) {
+		return false
+	}
+	for _, b := range append([]key.Binding{keys.Undo, keys.Redo}, editKeys[m.currentView]...) {
+		if slices.Contains(b.Keys(), k) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockEdit stops a key that would change a read-only space, saying why.
+func (m *model) blockEdit(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if !m.editsReadOnly(msg.String()) {
+		return false, nil
+	}
+	return true, m.toast("This space is read-only: duplicate it to make changes")
+}
+
+type exploreListItem struct {
+	Space Space
+}
+
+func (i exploreListItem) FilterValue() string { return i.Space.Name }
+func (i exploreListItem) Title() string       { return i.Space.Name }
+func (i exploreListItem) Description() string {
+	desc := "updated " + relativeTime(i.Space.UpdatedAt)
+	if len(i.Space.Users) > 0 && i.Space.Users[0].Name != "" {
+		desc = "by " + i.Space.Users[0].Name + " · " + desc
+	}
+	return desc
+}
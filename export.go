@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// byPosition sorts cards or boxes into reading order: top to bottom, then
+// left to right.
+func byPosition(x, y func(i int) int) func(i, j int) bool {
+	return func(i, j int) bool {
+		if y(i) != y(j) {
+			return y(i) < y(j)
+		}
+		return x(i) < x(j)
+	}
+}
+
+// spaceMarkdown converts a space to Markdown: cards outside any box come
+// first, then each box as a heading over its cards. Cards are bullets,
+// task cards keep their checkboxes, [[tags]] are left as they are, and
+// connections are footnotes on the card they start from.
+func spaceMarkdown(space Space) string {
+	cards := slices.Clone(space.Cards)
+	sort.SliceStable(cards, byPosition(func(i int) int { return cards[i].X }, func(i int) int { return cards[i].Y }))
+	boxes := slices.Clone(space.Boxes)
+	sort.SliceStable(boxes, byPosition(func(i int) int { return boxes[i].X }, func(i int) int { return boxes[i].Y }))
+
+	groups := map[string][]Card{}
+	names := map[string]string{}
+	for _, card := range cards {
+		box, _ := cardBox(space.Boxes, card)
+		groups[box.ID] = append(groups[box.ID], card)
+		names[card.ID] = firstLine(checkboxPattern.ReplaceAllString(card.Name, ""))
+	}
+	typeNames := map[string]string{}
+	for _, t := range space.ConnectionTypes {
+		typeNames[t.ID] = t.Name
+	}
+
+	var b strings.Builder
+	var footnotes []string
+	fmt.Fprintf(&b, "# %s\n", space.Name)
+	writeCards := func(cards []Card) {
+		b.WriteString("\n")
+		for _, card := range cards {
+			b.WriteString(markdownBullet(card.Name))
+			for _, conn := range space.Connections {
+				if conn.StartItemID != card.ID || names[conn.EndItemID] == "" {
+					continue
+				}
+				footnotes = append(footnotes, connectionFootnote(names[conn.EndItemID], conn.Label, typeNames[conn.ConnectionTypeID]))
+				fmt.Fprintf(&b, " [^%d]", len(footnotes))
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(groups[""]) > 0 {
+		writeCards(groups[""])
+	}
+	for _, box := range boxes {
+		if len(groups[box.ID]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n", box.Name)
+		writeCards(groups[box.ID])
+	}
+	if len(footnotes) > 0 {
+		b.WriteString("\n")
+		for i, note := range footnotes {
+			fmt.Fprintf(&b, "[^%d]: %s\n", i+1, note)
+		}
+	}
+	return b.String()
+}
+
+// markdownBullet turns a card's text into a list item, indenting any
+// further lines under the first.
+func markdownBullet(name string) string {
+	bullet := "- "
+	if isTask, done := cardCheckbox(name); isTask {
+		bullet = "- [ ] "
+		if done {
+			bullet = "- [x] "
+		}
+		name = checkboxPattern.ReplaceAllString(name, "")
+	}
+	lines := strings.Split(strings.TrimSpace(name), "\n")
+	return bullet + strings.Join(lines, "\n  ")
+}
+
+func connectionFootnote(to, label, typeName string) string {
+	note := "Connects to “" + to + "”"
+	switch {
+	case label != "":
+		note += " (" + label + ")"
+	case typeName != "":
+		note += " (" + typeName + ")"
+	}
+	return note
+}
+
+var fileNameUnsafe = regexp.MustCompile(`[^\pL\pN]+`)
+
+// exportFileName suggests a file name for a space's export, e.g.
+// "reading-list.md".
+func exportFileName(space Space, ext string) string {
+	name := strings.Trim(fileNameUnsafe.ReplaceAllString(strings.ToLower(space.Name), "-"), "-")
+	if name == "" {
+		name = space.ID
+	}
+	return name + ext
+}
+
+// exportSpace asks where to save the open space as Markdown, and saves
+// it there.
+func (m *model) exportSpace() tea.Cmd {
+	space := m.selectedSpace
+	return m.openPrompt("Export to", exportFileName(space, ".md"), func(path string) tea.Cmd {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return nil
+		}
+		return m.writeExport(path, spaceMarkdown(space))
+	})
+}
+
+// writeExport saves an export and says where it went.
+func (m *model) writeExport(path, content string) tea.Cmd {
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return func() tea.Msg { return fmt.Errorf("error exporting space: %v", err) }
+	}
+	return m.toast("Exported to " + path)
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := fs.String("o", "", "file to write to (default: standard output)")
+	format := fs.String("format", "markdown", "markdown, or dot or mermaid for the connection graph")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: kinopio-tui export <space name or id> [--format markdown|dot|mermaid] [-o <file>]")
+	}
+	convert, ok := exportFormats[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q: use markdown, dot or mermaid", *format)
+	}
+
+	found, err := findSpace(positional[0])
+	if err != nil {
+		return err
+	}
+	space, err := api.Space(context.Background(), found.ID)
+	if err != nil {
+		return err
+	}
+	content := convert(space)
+	if *output == "" {
+		fmt.Print(content)
+		return nil
+	}
+	if err := os.WriteFile(*output, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("error writing export: %v", err)
+	}
+	return nil
+}
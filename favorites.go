@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// favoritesMsg carries the IDs of the user's favorite spaces.
+type favoritesMsg struct {
+	ids map[string]bool
+}
+
+// fetchFavorites looks up the user's favorite spaces. The spaces list just
+// goes without them if that fails.
+func fetchFavorites() tea.Cmd {
+	return func() tea.Msg {
+		spaces, err := api.FavoriteSpaces(programCtx)
+		if err != nil {
+			return nil
+		}
+		ids := make(map[string]bool, len(spaces))
+		for _, space := range spaces {
+			ids[space.ID] = true
+		}
+		return favoritesMsg{ids: ids}
+	}
+}
+
+// splitFavorites separates the favorite spaces from the others, keeping
+// their order.
+func (m *model) splitFavorites(spaces []Space) (favorites, others []Space) {
+	for _, space := range spaces {
+		if m.favorites[space.ID] {
+			favorites = append(favorites, space)
+		} else {
+			others = append(others, space)
+		}
+	}
+	return favorites, others
+}
+
+// toggleFavorite stars the highlighted space in the spaces list, or
+// unstars it.
+func (m *model) toggleFavorite() tea.Cmd {
+	item, ok := m.list.SelectedItem().(listItem)
+	if !ok {
+		return nil
+	}
+	space := item.Space
+	favorite := !m.favorites[space.ID]
+	if m.favorites == nil {
+		m.favorites = map[string]bool{}
+	}
+	if favorite {
+		m.favorites[space.ID] = true
+	} else {
+		delete(m.favorites, space.ID)
+	}
+	m.keepCursor(m.showSpaces)
+	message := "Removed " + space.Name + " from favorites"
+	if favorite {
+		message = "Added " + space.Name + " to favorites"
+	}
+	return tea.Batch(apiCmd(func(ctx context.Context) error {
+		return api.SetFavoriteSpace(ctx, space.ID, favorite)
+	}), m.toast(message))
+}
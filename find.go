@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sahilm/fuzzy"
+)
+
+// cardFind searches the cards list without filtering it: matching cards
+// are highlighted in place, and n/N move between them.
+type cardFind struct {
+	input   textinput.Model
+	editing bool // The query is still being typed
+}
+
+func (i cardListItem) Highlights() []int { return i.matched }
+
+// openFind starts a search of the cards list, picking up the last query.
+func (m *model) openFind() tea.Cmd {
+	if m.find == nil {
+		input := textinput.New()
+		input.Prompt = "find: "
+		input.Placeholder = "find cards"
+		m.find = &cardFind{input: input}
+	}
+	m.find.editing = true
+	m.find.input.Width = m.width - 4
+	m.find.input.Focus()
+	return textinput.Blink
+}
+
+// closeFind ends the search and clears its highlights.
+func (m *model) closeFind() {
+	m.find = nil
+	if m.currentView == "cards" {
+		m.setCardItems()
+	}
+}
+
+// updateFind handles keys while the query is typed, moving to the first
+// match as it changes. Enter keeps the matches highlighted for n/N; esc
+// clears them.
+func (m *model) updateFind(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeFind()
+		return nil
+	case "enter":
+		if m.find.input.Value() == "" || len(m.findMatches()) == 0 {
+			m.closeFind()
+			return nil
+		}
+		m.find.editing = false
+		m.find.input.Blur()
+		return nil
+	}
+	var cmd tea.Cmd
+	query := m.find.input.Value()
+	m.find.input, cmd = m.find.input.Update(msg)
+	if m.find.input.Value() != query {
+		m.setCardItems()
+		m.nextMatch(0)
+	}
+	return cmd
+}
+
+// findMatch returns the positions in name that match the query, or nil if
+// it doesn't match.
+func (m *model) findMatch(name string) []int {
+	if m.find == nil || m.find.input.Value() == "" {
+		return nil
+	}
+	matches := fuzzy.Find(m.find.input.Value(), []string{name})
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches[0].MatchedIndexes
+}
+
+// findMatches returns the indexes of the matching items in the cards list.
+func (m *model) findMatches() []int {
+	var indexes []int
+	for i, item := range m.list.Items() {
+		if item, ok := item.(cardListItem); ok && len(item.matched) > 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// nextMatch moves the cursor to the next match after it, or the previous
+// one if step is -1, wrapping around. A step of 0 stays on the card under
+// the cursor if it matches.
+func (m *model) nextMatch(step int) {
+	matches := m.findMatches()
+	if len(matches) == 0 {
+		return
+	}
+	m.list.ResetFilter()
+	current := m.list.Index()
+	target := matches[0]
+	switch step {
+	case -1:
+		target = matches[len(matches)-1]
+		for i := len(matches) - 1; i >= 0; i-- {
+			if matches[i] < current {
+				target = matches[i]
+				break
+			}
+		}
+	default:
+		for _, i := range matches {
+			if i > current || (step == 0 && i == current) {
+				target = i
+				break
+			}
+		}
+	}
+	m.list.Select(target)
+}
+
+// findStatus describes the search for the help line, e.g. "Match 2 of 5".
+func (m *model) findStatus() string {
+	if m.find.editing {
+		return m.find.input.View()
+	}
+	matches := m.findMatches()
+	for n, i := range matches {
+		if i == m.list.Index() {
+			return fmt.Sprintf("Match %d of %d for %q: n/N for the next/previous match, / to change the search, esc to clear it.", n+1, len(matches), m.find.input.Value())
+		}
+	}
+	count := fmt.Sprintf("%d matches", len(matches))
+	if len(matches) == 1 {
+		count = "1 match"
+	}
+	return fmt.Sprintf("%s for %q: n/N for the next/previous match, / to change the search, esc to clear it.", count, m.find.input.Value())
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// relativeTime describes t relative to now, e.g. "3 days ago".
+func relativeTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute") + " ago"
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour") + " ago"
+	case d < 30*24*time.Hour:
+		return plural(int(d.Hours()/24), "day") + " ago"
+	case d < 365*24*time.Hour:
+		return plural(int(d.Hours()/24/30), "month") + " ago"
+	default:
+		return plural(int(d.Hours()/24/365), "year") + " ago"
+	}
+}
+
+// formatTimestamp shows t as an absolute time in the user's locale followed
+// by how long ago it was, e.g. "05/03/2024 14:02 (3 days ago)".
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "—"
+	}
+	return fmt.Sprintf("%s (%s)", displaySettings.formatDateTime(t), relativeTime(t))
+}
+
+// orNone shows an empty value as "—".
+func orNone(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+// plural formats a count with its noun, e.g. "1 card" or "2 cards".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%s %s", displaySettings.formatNumber(n), noun)
+	}
+	return fmt.Sprintf("%s %ss", displaySettings.formatNumber(n), noun)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type gitHubIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	HTMLURL     string    `json:"html_url"`
+	PullRequest *struct{} `json:"pull_request"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+var nextPagePattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// fetchGitHubIssues fetches every open issue in a repo, following
+// pagination. Pull requests, which the issues API also returns, are
+// skipped. GITHUB_TOKEN is used if set, for private repos and higher rate
+// limits.
+func fetchGitHubIssues(repo string) ([]gitHubIssue, error) {
+	var issues []gitHubIssue
+	url := fmt.Sprintf("https://api.github.com/repos/%s/issues?state=open&per_page=100", repo)
+	for url != "" {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %v", err)
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing request: %v", err)
+		}
+		var page []gitHubIssue
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch issues for %s: %s", repo, resp.Status)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshaling issues: %v", err)
+		}
+		for _, issue := range page {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+
+		url = ""
+		if match := nextPagePattern.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			url = match[1]
+		}
+	}
+	return issues, nil
+}
+
+// issueColumns lays issues out in a column per label, using each issue's
+// first label. Labels become [[tags]] on the cards.
+func issueColumns(issues []gitHubIssue) []column {
+	byLabel := map[string][]string{}
+	for _, issue := range issues {
+		label := "unlabeled"
+		var tags []string
+		for i, l := range issue.Labels {
+			if i == 0 {
+				label = l.Name
+			}
+			tags = append(tags, "[["+l.Name+"]]")
+		}
+		name := fmt.Sprintf("#%d %s\n%s", issue.Number, issue.Title, issue.HTMLURL)
+		if len(tags) > 0 {
+			name += "\n" + strings.Join(tags, " ")
+		}
+		byLabel[label] = append(byLabel[label], name)
+	}
+
+	var labels []string
+	for label := range byLabel {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	columns := make([]column, len(labels))
+	for i, label := range labels {
+		columns[i] = column{name: label, cards: byLabel[label]}
+	}
+	return columns
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// graphHubLimit is how many of the most-connected cards the graph view
+// lists.
+const graphHubLimit = 10
+
+// graphMetrics summarizes how a space's cards are connected.
+type graphMetrics struct {
+	degree     map[string]int // Connections touching each card
+	hubs       []Card         // Connected cards, most connections first
+	orphans    []Card         // Cards with no connections
+	components int            // Groups of cards linked to each other, including lone cards
+	clusters   int            // Components with more than one card
+}
+
+func computeGraphMetrics(space Space) graphMetrics {
+	metrics := graphMetrics{degree: map[string]int{}}
+
+	// Union-find over card IDs, so each component ends up with one root.
+	parent := make(map[string]string, len(space.Cards))
+	for _, card := range space.Cards {
+		parent[card.ID] = card.ID
+	}
+	var root func(id string) string
+	root = func(id string) string {
+		if parent[id] != id {
+			parent[id] = root(parent[id])
+		}
+		return parent[id]
+	}
+
+	for _, conn := range space.Connections {
+		_, startOK := parent[conn.StartItemID]
+		_, endOK := parent[conn.EndItemID]
+		if !startOK || !endOK {
+			continue
+		}
+		metrics.degree[conn.StartItemID]++
+		metrics.degree[conn.EndItemID]++
+		parent[root(conn.StartItemID)] = root(conn.EndItemID)
+	}
+
+	sizes := map[string]int{}
+	for _, card := range space.Cards {
+		sizes[root(card.ID)]++
+		if metrics.degree[card.ID] == 0 {
+			metrics.orphans = append(metrics.orphans, card)
+		} else {
+			metrics.hubs = append(metrics.hubs, card)
+		}
+	}
+	metrics.components = len(sizes)
+	for _, size := range sizes {
+		if size > 1 {
+			metrics.clusters++
+		}
+	}
+
+	sort.SliceStable(metrics.hubs, func(i, j int) bool {
+		return metrics.degree[metrics.hubs[i].ID] > metrics.degree[metrics.hubs[j].ID]
+	})
+	return metrics
+}
+
+// summary describes the metrics in a line.
+func (g graphMetrics) summary() string {
+	return fmt.Sprintf("%s · %s · %s", plural(g.components, "component"), plural(g.clusters, "cluster"), plural(len(g.orphans), "orphan"))
+}
+
+func (m *model) showGraph() {
+	m.currentView = "graph"
+	metrics := computeGraphMetrics(m.selectedSpace)
+	m.list.Title = m.selectedSpace.Name + " → Graph · " + metrics.summary()
+
+	var items []list.Item
+	if len(metrics.hubs) > 0 {
+		items = append(items, sectionItem{"Most connected"})
+		for _, card := range metrics.hubs[:min(graphHubLimit, len(metrics.hubs))] {
+			items = append(items, graphCardItem{card, metrics.degree[card.ID]})
+		}
+	}
+	if len(metrics.orphans) > 0 {
+		items = append(items, sectionItem{fmt.Sprintf("Orphans (%d)", len(metrics.orphans))})
+		for _, card := range sortCards(metrics.orphans, sortReading) {
+			items = append(items, graphCardItem{card, 0})
+		}
+	}
+	m.setItems(items)
+}
+
+type graphCardItem struct {
+	Card   Card
+	degree int
+}
+
+func (i graphCardItem) FilterValue() string { return i.Card.Name }
+func (i graphCardItem) Title() string       { return firstLine(i.Card.Name) }
+func (i graphCardItem) Description() string {
+	if i.degree == 0 {
+		return "No connections"
+	}
+	return plural(i.degree, "connection")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportFormats convert a space for the export command, by --format.
+var exportFormats = map[string]func(Space) string{
+	"markdown": spaceMarkdown,
+	"dot":      spaceDOT,
+	"mermaid":  spaceMermaid,
+}
+
+// graphLabel is how a card is labeled in a graph: its first line, without
+// a task's checkbox.
+func graphLabel(card Card) string {
+	return firstLine(checkboxPattern.ReplaceAllString(card.Name, ""))
+}
+
+// connectionLabel is a connection's label, or its type's name if it has
+// none.
+func connectionLabel(space Space, conn Connection) string {
+	if conn.Label != "" {
+		return conn.Label
+	}
+	for _, t := range space.ConnectionTypes {
+		if t.ID == conn.ConnectionTypeID {
+			return t.Name
+		}
+	}
+	return ""
+}
+
+// spaceDOT converts a space's cards and connections to a Graphviz graph,
+// with each box as a cluster around its cards.
+func spaceDOT(space Space) string {
+	quote := func(s string) string {
+		s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+		return `"` + s + `"`
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", quote(space.Name))
+	b.WriteString("  node [shape=box];\n")
+	boxed := map[string][]Card{}
+	for _, card := range space.Cards {
+		box, _ := cardBox(space.Boxes, card)
+		boxed[box.ID] = append(boxed[box.ID], card)
+	}
+	for _, card := range boxed[""] {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", quote(card.ID), quote(graphLabel(card)))
+	}
+	for i, box := range space.Boxes {
+		if len(boxed[box.ID]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n    label=%s;\n", i, quote(box.Name))
+		for _, card := range boxed[box.ID] {
+			fmt.Fprintf(&b, "    %s [label=%s];\n", quote(card.ID), quote(graphLabel(card)))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, conn := range space.Connections {
+		fmt.Fprintf(&b, "  %s -> %s", quote(conn.StartItemID), quote(conn.EndItemID))
+		if label := connectionLabel(space, conn); label != "" {
+			fmt.Fprintf(&b, " [label=%s]", quote(label))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// spaceMermaid converts a space's cards and connections to a Mermaid
+// flowchart, with each box as a subgraph around its cards.
+func spaceMermaid(space Space) string {
+	// Card IDs aren't always valid Mermaid IDs, so number the cards.
+	ids := map[string]string{}
+	for i, card := range space.Cards {
+		ids[card.ID] = fmt.Sprintf("c%d", i+1)
+	}
+	text := func(s string) string {
+		return strings.NewReplacer(`"`, "#quot;", "|", "#124;").Replace(s)
+	}
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	boxed := map[string][]Card{}
+	for _, card := range space.Cards {
+		box, _ := cardBox(space.Boxes, card)
+		boxed[box.ID] = append(boxed[box.ID], card)
+	}
+	for _, card := range boxed[""] {
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[card.ID], text(graphLabel(card)))
+	}
+	for i, box := range space.Boxes {
+		if len(boxed[box.ID]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  subgraph b%d[\"%s\"]\n", i+1, text(box.Name))
+		for _, card := range boxed[box.ID] {
+			fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[card.ID], text(graphLabel(card)))
+		}
+		b.WriteString("  end\n")
+	}
+	for _, conn := range space.Connections {
+		from, to := ids[conn.StartItemID], ids[conn.EndItemID]
+		if from == "" || to == "" {
+			continue
+		}
+		if label := connectionLabel(space, conn); label != "" {
+			fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", from, text(label), to)
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", from, to)
+		}
+	}
+	return b.String()
+}
+
+// exportGraph asks where to save the open space's graph, and saves it as
+// Graphviz DOT, or as Mermaid if the file name ends in .mmd or .md.
+func (m *model) exportGraph() tea.Cmd {
+	space := m.selectedSpace
+	return m.openPrompt("Export graph to", exportFileName(space, ".dot"), func(path string) tea.Cmd {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return nil
+		}
+		convert := spaceDOT
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".mmd", ".mermaid":
+			convert = spaceMermaid
+		case ".md":
+			// Mermaid renders from a fenced block in Markdown.
+			convert = func(space Space) string { return "```mermaid\n" + spaceMermaid(space) + "```\n" }
+		}
+		return m.writeExport(path, convert(space))
+	})
+}
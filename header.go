@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inSpace reports whether the current view belongs to the selected space,
+// which is when the space header is shown.
+func (m *model) inSpace() bool {
+	switch m.currentView {
+	case "list", "allTags", "tagCards", "explore", "templates", "notifications", "account":
+		return false
+	}
+	return true
+}
+
+// headerView renders the pinned header: the breadcrumb, then the selected
+// space's metadata.
+func (m *model) headerView() string {
+	space := m.selectedSpace
+	parts := []string{m.breadcrumb()}
+	if space.Privacy != "" {
+		parts = append(parts, space.Privacy)
+	}
+	parts = append(parts,
+		plural(len(space.Collaborators), "collaborator"),
+		"updated "+relativeTime(space.UpdatedAt),
+	)
+	return headerStyle.Width(m.width).MaxWidth(m.width).Render(strings.Join(parts, " · "))
+}
+
+// resize fits the list into the space left over by the header, help line
+// and status bar.
+func (m *model) resize() {
+	height := m.height - 4 - lipgloss.Height(m.statusBarView())
+	if m.inSpace() {
+		height -= lipgloss.Height(m.headerView())
+	}
+	width := m.width
+	if m.splitActive() {
+		width = m.listPaneWidth()
+		if m.previewBelow() {
+			height -= previewHeight + previewBelowStyle.GetVerticalFrameSize()
+		}
+	}
+	m.list.SetSize(width, height)
+}
@@ -0,0 +1,137 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// location is a place in the app that history navigation can return to.
+type location struct {
+	view    string
+	spaceID string
+	boxID   string
+	tag     string
+	cardID  string
+}
+
+// location returns where the user currently is.
+func (m *model) location() location {
+	loc := location{view: m.currentView}
+	if m.inSpace() {
+		loc.spaceID = m.selectedSpace.ID
+	}
+	if m.currentView == "cards" && m.boxFilter != nil {
+		loc.boxID = m.boxFilter.ID
+	}
+	if m.currentView == "cards" {
+		loc.tag = m.tagFilter
+	}
+	if m.currentView == "cardDetails" {
+		loc.cardID = m.selectedCard.ID
+	}
+	return loc
+}
+
+// recordLocation pushes the previous location onto the back stack whenever
+// the user ends up somewhere new, like a browser does on navigation. While
+// a location's space is loading, the user hasn't got there yet.
+func (m *model) recordLocation() {
+	if m.pendingLocation != nil {
+		return
+	}
+	loc := m.location()
+	if loc == m.here {
+		return
+	}
+	if m.here.view != "" {
+		m.back = append(m.back, m.here)
+	}
+	m.forward = nil
+	m.here = loc
+}
+
+func (m *model) goBack() tea.Cmd {
+	if len(m.back) == 0 {
+		return nil
+	}
+	loc := m.back[len(m.back)-1]
+	m.back = m.back[:len(m.back)-1]
+	m.forward = append(m.forward, m.here)
+	return m.goTo(loc)
+}
+
+func (m *model) goForward() tea.Cmd {
+	if len(m.forward) == 0 {
+		return nil
+	}
+	loc := m.forward[len(m.forward)-1]
+	m.forward = m.forward[:len(m.forward)-1]
+	m.back = append(m.back, m.here)
+	return m.goTo(loc)
+}
+
+// goTo navigates to loc without recording it as a new visit, fetching the
+// location's space first if it isn't the one already loaded.
+func (m *model) goTo(loc location) tea.Cmd {
+	m.here = loc
+	if loc.spaceID != "" && loc.spaceID != m.selectedSpace.ID {
+		m.pendingLocation = &loc
+		return m.openSpace(Space{ID: loc.spaceID})
+	}
+	m.restoreLocation(loc)
+	return nil
+}
+
+// restoreLocation shows loc, whose space must already be loaded.
+func (m *model) restoreLocation(loc location) {
+	m.boxFilter, m.tagFilter = nil, ""
+	switch loc.view {
+	case "list":
+		m.showSpaces()
+	case "details":
+		m.showDetails()
+	case "boxes":
+		m.showBoxes()
+	case "connections":
+		m.showConnections()
+	case "graph":
+		m.showGraph()
+	case "spaceActions":
+		m.showSpaceActions()
+	case "tags":
+		m.showTags()
+	case "kanban":
+		m.showKanban()
+	case "canvas":
+		m.showCanvas()
+	case "allTags", "tagCards":
+		m.showUserTags(m.userTags)
+	case "explore":
+		m.showExplore()
+	case "templates":
+		m.showTemplates()
+	case "notifications":
+		m.showNotifications()
+	case "account":
+		m.showAccount()
+	case "cards", "cardDetails", "bulkActions":
+		m.boxFilter = nil
+		m.tagFilter = loc.tag
+		for _, box := range m.selectedSpace.Boxes {
+			if box.ID == loc.boxID {
+				box := box
+				m.boxFilter = &box
+			}
+		}
+		m.showCards()
+		if card, ok := m.cardByID(loc.cardID); ok && loc.view == "cardDetails" {
+			m.selectedCard = card
+			m.currentView = "cardDetails"
+			m.showCardDetails()
+		} else if loc.cardID != "" {
+			m.selectCard(loc.cardID)
+		}
+	default:
+		// Result views like dead links aren't kept around, so fall back
+		// to the space they belong to.
+		m.showDetails()
+	}
+	m.here = m.location()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// arrive delivers a space that was being fetched, then records the
+// location like Update does.
+func arrive(m *model, space Space) {
+	m.update(spaceDetailsMsg{Space: space})
+	m.recordLocation()
+}
+
+func TestHistoryAcrossSpaces(t *testing.T) {
+	a, b := Space{ID: "a", Name: "A"}, Space{ID: "b", Name: "B"}
+	m := &model{
+		list:       list.New(nil, list.NewDefaultDelegate(), 80, 20),
+		spaceCache: map[string]Space{},
+		selected:   map[string]bool{},
+	}
+	m.selectedSpace = a
+	m.showDetails()
+	m.recordLocation()
+	m.selectedSpace = b
+	m.showDetails()
+	m.recordLocation()
+
+	inA := location{view: "details", spaceID: "a"}
+	inB := location{view: "details", spaceID: "b"}
+	check := func(step string, here location, back, forward []location) {
+		t.Helper()
+		if m.here != here || !reflect.DeepEqual(m.back, back) || !reflect.DeepEqual(m.forward, forward) {
+			t.Fatalf("after %s: here %+v, back %+v, forward %+v; want %+v, %+v, %+v", step, m.here, m.back, m.forward, here, back, forward)
+		}
+	}
+	check("visiting B", inB, []location{inA}, nil)
+
+	m.goBack()
+	m.recordLocation()
+	arrive(m, a)
+	check("going back", inA, []location{}, []location{inB})
+
+	// B was kept in the cache on the way out, so it opens straight away.
+	m.goForward()
+	m.recordLocation()
+	check("going forward", inB, []location{inA}, []location{})
+}
+
+func TestSpaceArrivingAfterCancel(t *testing.T) {
+	a, b := Space{ID: "a", Name: "A"}, Space{ID: "b", Name: "B"}
+	m := &model{
+		list:       list.New(nil, list.NewDefaultDelegate(), 80, 20),
+		spaceCache: map[string]Space{},
+		selected:   map[string]bool{},
+	}
+	m.selectedSpace = a
+	m.showDetails()
+
+	m.openSpace(Space{ID: "b"})
+	if !m.loading {
+		t.Fatal("opening an uncached space should show the loading screen")
+	}
+	m.cancelOpen()
+	m.update(spaceDetailsMsg{Space: b})
+	if m.selectedSpace.ID != "a" || m.currentView != "details" {
+		t.Fatalf("a cancelled space took over: showing %q in %s", m.currentView, m.selectedSpace.ID)
+	}
+	if _, ok := m.spaceCache["b"]; !ok {
+		t.Fatal("a cancelled space should still be cached")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inboxName is the name Kinopio gives every user's inbox space.
+const inboxName = "Inbox"
+
+func isInbox(space Space) bool {
+	return strings.EqualFold(space.Name, inboxName)
+}
+
+// listedSpaces returns the spaces shown below the pinned Inbox entry.
+func (m *model) listedSpaces() []Space {
+	var spaces []Space
+	for _, space := range m.spaces {
+		if !isInbox(space) {
+			spaces = append(spaces, space)
+		}
+	}
+	return spaces
+}
+
+// openInbox opens the inbox space, fetching it directly if it isn't in
+// the spaces list.
+func (m *model) openInbox() tea.Cmd {
+	for _, space := range m.spaces {
+		if isInbox(space) {
+			return m.openSpace(space)
+		}
+	}
+	m.loading = true
+	m.opening = Space{Name: inboxName}
+	return tea.Batch(func() tea.Msg {
+		space, err := api.Inbox(programCtx)
+		if err != nil {
+			return err
+		}
+		return spaceDetailsMsg{Space: space}
+	}, m.spinner.Tick)
+}
+
+// captureToInbox prompts for a card and sends it straight to the inbox.
+func (m *model) captureToInbox() tea.Cmd {
+	return m.openPrompt("Add to inbox", "", func(name string) tea.Cmd {
+		if strings.TrimSpace(name) == "" {
+			return nil
+		}
+		card := Card{ID: newID(), Name: name}
+		return sendWrite(queuedWrite{Kind: "inbox", Cards: []Card{card}})
+	})
+}
+
+// inboxListItem is the Inbox entry pinned to the top of the spaces list.
+type inboxListItem struct{}
+
+func (i inboxListItem) Prefix() string      { return "📥 " }
+func (i inboxListItem) FilterValue() string { return inboxName }
+func (i inboxListItem) Title() string       { return inboxName }
+func (i inboxListItem) Description() string { return "Cards captured with i, from anywhere" }
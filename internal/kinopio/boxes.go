@@ -0,0 +1,31 @@
+package kinopio
+
+import "context"
+
+// CreateBox saves a new box to a space.
+func (c *Client) CreateBox(ctx context.Context, spaceID string, box Box) error {
+	body := map[string]interface{}{
+		"id":           box.ID,
+		"name":         box.Name,
+		"x":            box.X,
+		"y":            box.Y,
+		"resizeWidth":  box.ResizeWidth,
+		"resizeHeight": box.ResizeHeight,
+		"spaceId":      spaceID,
+	}
+	if box.Color != "" {
+		body["color"] = box.Color
+	}
+	return c.do(ctx, "POST", "/box", body, nil, "create box")
+}
+
+// UpdateBox PATCHes the given fields of a box. fields must include the
+// box's id.
+func (c *Client) UpdateBox(ctx context.Context, fields map[string]interface{}) error {
+	return c.do(ctx, "PATCH", "/box", fields, nil, "update box")
+}
+
+// RemoveBox deletes a box. The cards inside it are left where they are.
+func (c *Client) RemoveBox(ctx context.Context, boxID string) error {
+	return c.do(ctx, "DELETE", "/box", map[string]interface{}{"id": boxID}, nil, "remove box")
+}
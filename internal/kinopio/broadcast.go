@@ -0,0 +1,74 @@
+package kinopio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// BroadcastMessage is a change to a space sent over the broadcast
+// WebSocket, such as "updateCard" or "removeConnection". Updates holds
+// the changed item's fields, always including its id.
+type BroadcastMessage struct {
+	Message string          `json:"message"`
+	Updates json.RawMessage `json:"updates"`
+	SpaceID string          `json:"spaceId"`
+}
+
+// Broadcast is a live connection to a space's changes.
+type Broadcast struct {
+	conn *websocket.Conn
+}
+
+// JoinSpace connects to the broadcast server and joins a space, so that
+// changes made by collaborators are sent to the returned Broadcast.
+// clientID identifies this client, so its own changes aren't echoed back.
+func (c *Client) JoinSpace(ctx context.Context, spaceID, clientID string) (*Broadcast, error) {
+	if c.APIKey == "" {
+		return nil, ErrNoAPIKey
+	}
+	url := c.BaseURL
+	url = strings.Replace(url, "https://", "wss://", 1)
+	url = strings.Replace(url, "http://", "ws://", 1)
+
+	header := http.Header{"Authorization": {c.APIKey}}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to broadcast server: %w", err)
+	}
+	join := map[string]interface{}{
+		"message":  "joinSpace",
+		"space":    map[string]interface{}{"id": spaceID},
+		"clientId": clientID,
+		"apiKey":   c.APIKey,
+	}
+	if err := conn.WriteJSON(join); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error joining space: %w", err)
+	}
+	return &Broadcast{conn: conn}, nil
+}
+
+// Next waits for the next change. Messages that aren't changes, such as
+// other users' cursor positions, are skipped.
+func (b *Broadcast) Next() (BroadcastMessage, error) {
+	for {
+		_, data, err := b.conn.ReadMessage()
+		if err != nil {
+			return BroadcastMessage{}, err
+		}
+		var msg BroadcastMessage
+		if json.Unmarshal(data, &msg) == nil && len(msg.Updates) > 0 && string(msg.Updates) != "null" {
+			return msg, nil
+		}
+	}
+}
+
+// Close leaves the space.
+func (b *Broadcast) Close() error {
+	return b.conn.Close()
+}
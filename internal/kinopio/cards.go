@@ -0,0 +1,81 @@
+package kinopio
+
+import "context"
+
+func newCardBody(spaceID string, card Card) map[string]interface{} {
+	body := map[string]interface{}{
+		"id":      card.ID,
+		"name":    card.Name,
+		"x":       card.X,
+		"y":       card.Y,
+		"spaceId": spaceID,
+	}
+	if card.BackgroundColor != "" {
+		body["backgroundColor"] = card.BackgroundColor
+	}
+	if card.IsComment {
+		body["isComment"] = true
+	}
+	return body
+}
+
+// Card fetches a card by its ID.
+func (c *Client) Card(ctx context.Context, cardID string) (Card, error) {
+	var card Card
+	err := c.do(ctx, "GET", "/card/"+cardID, nil, &card, "fetch card")
+	return card, err
+}
+
+// CreateCard saves a new card to a space and returns it as saved.
+func (c *Client) CreateCard(ctx context.Context, spaceID string, card Card) (Card, error) {
+	saved := card
+	err := c.do(ctx, "POST", "/card", newCardBody(spaceID, card), &saved, "create card")
+	return saved, err
+}
+
+// CreateCards saves new cards to a space in a single request.
+func (c *Client) CreateCards(ctx context.Context, spaceID string, cards []Card) error {
+	body := make([]map[string]interface{}, len(cards))
+	for i, card := range cards {
+		body[i] = newCardBody(spaceID, card)
+	}
+	return c.do(ctx, "POST", "/card/multiple", map[string]interface{}{"cards": body}, nil, "create cards")
+}
+
+// UpdateCard PATCHes the given fields of a card. fields must include the
+// card's id.
+func (c *Client) UpdateCard(ctx context.Context, fields map[string]interface{}) error {
+	return c.do(ctx, "PATCH", "/card", fields, nil, "update card")
+}
+
+// UpdateCards PATCHes several cards in a single request. Each entry must
+// include the card's id.
+func (c *Client) UpdateCards(ctx context.Context, cards []map[string]interface{}) error {
+	return c.do(ctx, "PATCH", "/card/multiple", map[string]interface{}{"cards": cards}, nil, "update cards")
+}
+
+// RemoveCard removes a card. Removed cards stay in the space's removed
+// cards and can be restored.
+func (c *Client) RemoveCard(ctx context.Context, cardID string) error {
+	return c.do(ctx, "DELETE", "/card", map[string]interface{}{"id": cardID}, nil, "remove card")
+}
+
+// RestoreCard brings back a removed card.
+func (c *Client) RestoreCard(ctx context.Context, cardID string) error {
+	return c.do(ctx, "PATCH", "/card/restore", map[string]interface{}{"id": cardID}, nil, "restore card")
+}
+
+// RemovedCards fetches the cards that have been removed from a space and
+// can still be restored.
+func (c *Client) RemovedCards(ctx context.Context, spaceID string) ([]Card, error) {
+	var cards []Card
+	err := c.do(ctx, "GET", "/space/"+spaceID+"/removed-cards", nil, &cards, "fetch removed cards")
+	return cards, err
+}
+
+// CreateCardInInbox saves a new card to the user's inbox space. Only the
+// card's ID and name are used; Kinopio places it.
+func (c *Client) CreateCardInInbox(ctx context.Context, card Card) error {
+	body := map[string]interface{}{"id": card.ID, "name": card.Name}
+	return c.do(ctx, "POST", "/card/to-inbox", body, nil, "add card to inbox")
+}
@@ -0,0 +1,223 @@
+// Package kinopio is a client for the Kinopio API
+// (https://help.kinopio.club/api/).
+package kinopio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the address of the hosted Kinopio API.
+	DefaultBaseURL = "https://api.kinopio.club"
+	// DefaultTimeout is how long NewClient's clients wait for a request.
+	DefaultTimeout = 30 * time.Second
+	// DefaultRetries is how many times NewClient's clients retry a failed
+	// request.
+	DefaultRetries = 3
+
+	retryBaseDelay = 500 * time.Millisecond // Doubled for each retry
+)
+
+// Client sends requests to the Kinopio API. Its fields can be changed
+// before use, e.g. to point at another server or to inject an
+// http.Client for tests.
+type Client struct {
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+
+	// Timeout limits each attempt at a request, including reading its
+	// response. Zero means no limit.
+	Timeout time.Duration
+	// Retries is how many more times a request is tried when the API
+	// can't be reached or answers with a server error. POST requests,
+	// which may have taken effect, are never retried.
+	Retries int
+	// OnRetry, if set, is called before waiting to retry a request.
+	OnRetry func(Retry)
+	// Limiter, if set, keeps requests under the API's rate limit.
+	Limiter *Limiter
+	// Cache, if set, lets unchanged responses be reused.
+	Cache ResponseCache
+}
+
+// Retry describes a request about to be retried.
+type Retry struct {
+	Attempt int           // The retry about to be made, from 1
+	Wait    time.Duration // How long until it's made
+	Err     error         // Why the last attempt failed
+
+	RateLimited bool // The API asked for fewer requests
+}
+
+// NewClient returns a client for the hosted API that authenticates with
+// apiKey.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		APIKey:     apiKey,
+		HTTPClient: http.DefaultClient,
+		Timeout:    DefaultTimeout,
+		Retries:    DefaultRetries,
+		Limiter:    NewLimiter(DefaultRateLimit, DefaultRateBurst),
+	}
+}
+
+// do sends a request. body, if non-nil, is sent as JSON, and the JSON
+// response is decoded into out if out is non-nil. action describes the
+// request in errors, e.g. "fetch spaces".
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}, action string) error {
+	if c.APIKey == "" {
+		return ErrNoAPIKey
+	}
+	return c.request(ctx, method, path, body, out, action)
+}
+
+// request is do without the API key check, for the few endpoints that
+// don't need one.
+func (c *Client) request(ctx context.Context, method, path string, body, out interface{}, action string) error {
+	resp, err := c.send(ctx, method, path, body, action)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %v", err)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("error unmarshaling response: %v", err)
+		}
+	}
+	return nil
+}
+
+// send makes a request and checks its status, returning the response with
+// its body still to be read and closed. Failures that may be temporary are
+// retried with exponential backoff, as Retries allows. When the API says
+// it's rate limiting, every request waits as long as it asks.
+func (c *Client) send(ctx context.Context, method, path string, body interface{}, action string) (*http.Response, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request: %v", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(ctx, method, path, data, action)
+		if err == nil || attempt >= c.Retries {
+			return resp, err
+		}
+		wait := retryBaseDelay << attempt
+		var apiErr *APIError
+		limited := errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+		switch {
+		case limited:
+			// The request wasn't handled, so even a POST can be retried.
+			if apiErr.RetryAfter > 0 {
+				wait = apiErr.RetryAfter
+			}
+			if c.Limiter != nil {
+				c.Limiter.Pause(wait)
+			}
+		case method == http.MethodPost || !retryable(err):
+			return resp, err
+		}
+		if c.OnRetry != nil {
+			c.OnRetry(Retry{Attempt: attempt + 1, Wait: wait, Err: err, RateLimited: limited})
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// attempt makes a request once.
+func (c *Client) attempt(ctx context.Context, method, path string, data []byte, action string) (*http.Response, error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("error performing request: %w", err)
+		}
+	}
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+	cancel := context.CancelFunc(func() {})
+	if c.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", c.APIKey)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	cached := c.revalidate(req, path)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("error performing request: %w", err)
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	c.useCache(resp, path, cached)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading response body: %v", err)
+		}
+		return nil, newAPIError(action, resp, respBody)
+	}
+	return resp, nil
+}
+
+// retryable reports whether a failed request might succeed if it's made
+// again: the API couldn't be reached, or had a server error. Requests
+// cancelled by the caller aren't.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return IsUnreachable(err)
+}
+
+// cancelOnClose releases a request's timeout once its response has been
+// read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,237 @@
+package kinopio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClient returns a client for srv that doesn't retry or wait.
+func testClient(srv *httptest.Server) *Client {
+	return &Client{BaseURL: srv.URL, APIKey: "test", HTTPClient: srv.Client()}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited}
+	for status, want := range map[int]error{
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrForbidden,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusInternalServerError: nil,
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"message": "no"}`))
+		}))
+		_, err := testClient(srv).Spaces(context.Background())
+		srv.Close()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != status || apiErr.Details["message"] != "no" {
+			t.Errorf("%d: got %v, want an APIError with the details", status, err)
+		}
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == want) {
+				t.Errorf("%d: errors.Is(err, %v) = %v", status, sentinel, got)
+			}
+		}
+	}
+}
+
+// memoryCache is a ResponseCache in memory.
+type memoryCache map[string][2]string
+
+func (c memoryCache) Get(path string) (string, []byte, bool) {
+	entry, ok := c[path]
+	return entry[0], []byte(entry[1]), ok
+}
+
+func (c memoryCache) Set(path, etag string, body []byte) {
+	c[path] = [2]string{etag, string(body)}
+}
+
+func TestNotModifiedUsesCache(t *testing.T) {
+	var downloads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"id": "s1", "name": "Ideas"}]`))
+	}))
+	defer srv.Close()
+	c := testClient(srv)
+	c.Cache = memoryCache{}
+
+	for i := 0; i < 2; i++ {
+		spaces, err := c.Spaces(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(spaces) != 1 || spaces[0].Name != "Ideas" {
+			t.Fatalf("fetch %d: got %+v", i+1, spaces)
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("downloaded the spaces %d times, want once", downloads)
+	}
+}
+
+func TestRateLimitedWaitsAsAsked(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	c := testClient(srv)
+	c.Retries = 1
+	c.Limiter = NewLimiter(DefaultRateLimit, DefaultRateBurst)
+	ctx, cancel := context.WithCancel(context.Background())
+	var retry Retry
+	c.OnRetry = func(r Retry) {
+		retry = r
+		cancel() // Don't actually wait
+	}
+
+	// Even a POST is retried, since the API didn't handle it.
+	err := c.CreateCards(ctx, "s1", []Card{{ID: "c1"}})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got %v, want ErrRateLimited", err)
+	}
+	if !retry.RateLimited || retry.Wait != 7*time.Second {
+		t.Errorf("retry = %+v, want a rate-limited wait of 7s", retry)
+	}
+	if paused := time.Until(c.Limiter.pausedUntil); paused < 6*time.Second {
+		t.Errorf("limiter paused for %v, want about 7s", paused)
+	}
+}
+
+func TestServerErrorsBackOff(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	c := testClient(srv)
+	c.Retries = 2
+	var waits []time.Duration
+	c.OnRetry = func(r Retry) { waits = append(waits, r.Wait) }
+
+	if _, err := c.Spaces(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 || len(waits) != 1 || waits[0] != retryBaseDelay {
+		t.Errorf("%d attempts, waits %v; want 2 attempts after waiting %v", attempts, waits, retryBaseDelay)
+	}
+}
+
+func TestNeverSent(t *testing.T) {
+	// Nothing listens on a port that was just closed.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	c := &Client{BaseURL: "http://" + addr, APIKey: "test"}
+	_, err = c.Spaces(context.Background())
+	if !NeverSent(err) || !IsUnreachable(err) {
+		t.Errorf("refused connection: NeverSent %v, IsUnreachable %v; want both", NeverSent(err), IsUnreachable(err))
+	}
+
+	// A request that times out may have been handled.
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+	c = testClient(srv)
+	c.Timeout = 50 * time.Millisecond
+	_, err = c.CreateCard(context.Background(), "s1", Card{ID: "c1"})
+	if NeverSent(err) || !IsUnreachable(err) {
+		t.Errorf("timeout: NeverSent %v, IsUnreachable %v; want only IsUnreachable", NeverSent(err), IsUnreachable(err))
+	}
+}
+
+// cardServer keeps cards the way the API does, for the card endpoints.
+type cardServer struct {
+	mu    sync.Mutex
+	cards map[string]map[string]interface{}
+}
+
+func (s *cardServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Method == http.MethodGet {
+		card, ok := s.cards[r.URL.Path[len("/card/"):]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(card)
+		return
+	}
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, _ := body["id"].(string)
+	switch r.Method {
+	case http.MethodPost:
+		s.cards[id] = body
+		json.NewEncoder(w).Encode(body)
+	case http.MethodPatch:
+		for name, value := range body {
+			s.cards[id][name] = value
+		}
+	case http.MethodDelete:
+		delete(s.cards, id)
+	}
+}
+
+func TestCardRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(&cardServer{cards: map[string]map[string]interface{}{}})
+	defer srv.Close()
+	c := testClient(srv)
+	ctx := context.Background()
+
+	saved, err := c.CreateCard(ctx, "s1", Card{ID: "c1", Name: "Draft", X: 10, Y: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID != "c1" || saved.Name != "Draft" {
+		t.Fatalf("created %+v", saved)
+	}
+	if err := c.UpdateCard(ctx, map[string]interface{}{"id": "c1", "name": "Final"}); err != nil {
+		t.Fatal(err)
+	}
+	card, err := c.Card(ctx, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Name != "Final" || card.X != 10 || card.Y != 20 {
+		t.Errorf("after update: %+v", card)
+	}
+	if err := c.RemoveCard(ctx, "c1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Card(ctx, "c1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("after remove: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,38 @@
+package kinopio
+
+import "context"
+
+// CreateConnection saves a new connection between two cards in a space.
+func (c *Client) CreateConnection(ctx context.Context, spaceID string, conn Connection) error {
+	body := map[string]interface{}{
+		"id":               conn.ID,
+		"connectionTypeId": conn.ConnectionTypeID,
+		"startItemId":      conn.StartItemID,
+		"endItemId":        conn.EndItemID,
+		"label":            conn.Label,
+		"spaceId":          spaceID,
+	}
+	return c.do(ctx, "POST", "/connection", body, nil, "create connection")
+}
+
+// CreateConnectionType saves a new kind of connection to a space.
+func (c *Client) CreateConnectionType(ctx context.Context, spaceID string, t ConnectionType) error {
+	body := map[string]interface{}{
+		"id":      t.ID,
+		"name":    t.Name,
+		"color":   t.Color,
+		"spaceId": spaceID,
+	}
+	return c.do(ctx, "POST", "/connection-type", body, nil, "create connection type")
+}
+
+// UpdateConnection PATCHes the given fields of a connection. fields must
+// include the connection's id.
+func (c *Client) UpdateConnection(ctx context.Context, fields map[string]interface{}) error {
+	return c.do(ctx, "PATCH", "/connection", fields, nil, "update connection")
+}
+
+// RemoveConnection deletes a connection.
+func (c *Client) RemoveConnection(ctx context.Context, connectionID string) error {
+	return c.do(ctx, "DELETE", "/connection", map[string]interface{}{"id": connectionID}, nil, "remove connection")
+}
@@ -0,0 +1,96 @@
+package kinopio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrNoAPIKey is returned when a request is made without an API key.
+	ErrNoAPIKey = errors.New("API key is not set")
+	// ErrUnauthorized matches API errors for a missing or rejected API key.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden matches API errors for things the API key's user isn't
+	// allowed to see or change, like someone else's private space.
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound matches API errors for things that don't exist, or that
+	// the user can't see.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited matches API errors for too many requests.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// APIError is returned when the API responds with an error status.
+type APIError struct {
+	Action     string                 // What was being done, e.g. "fetch spaces"
+	StatusCode int                    // HTTP status code
+	Status     string                 // HTTP status line, e.g. "404 Not Found"
+	Details    map[string]interface{} // The decoded JSON error body, if it was JSON
+	Body       string                 // The raw response body
+	RetryAfter time.Duration          // How long to wait before trying again, if the API said
+}
+
+func newAPIError(action string, resp *http.Response, body []byte) *APIError {
+	err := &APIError{
+		Action:     action,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+		RetryAfter: retryAfter(resp),
+	}
+	if json.Unmarshal(body, &err.Details) != nil {
+		err.Details = nil
+	}
+	return err
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == http.StatusTooManyRequests {
+		return fmt.Sprintf("failed to %s: Kinopio is limiting how many requests can be made. Try again in a minute.", e.Action)
+	}
+	if e.Details == nil {
+		return fmt.Sprintf("failed to %s: %s\nResponse body: %s", e.Action, e.Status, e.Body)
+	}
+	details, _ := json.MarshalIndent(e.Details, "", "  ")
+	return fmt.Sprintf("failed to %s: %s\nError details:\n%s", e.Action, e.Status, details)
+}
+
+// Is lets errors.Is match an APIError against ErrUnauthorized,
+// ErrForbidden, ErrNotFound and ErrRateLimited by its status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// IsUnreachable reports whether err means the API couldn't be reached at
+// all, e.g. because the network is down, rather than the API answering
+// with an error.
+func IsUnreachable(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// NeverSent reports whether err means a request never left the machine:
+// the API's address couldn't be looked up or connected to. Other errors,
+// like timeouts, may come after the API got the request.
+func NeverSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,70 @@
+package kinopio
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// ResponseCache keeps the responses to GET requests, keyed by path, along
+// with their ETags. When a Client has one, it asks the API whether a
+// cached response is still current and, if it is, uses it instead of
+// downloading it again.
+type ResponseCache interface {
+	Get(path string) (etag string, body []byte, ok bool)
+	Set(path, etag string, body []byte)
+}
+
+// revalidate makes req conditional on the cached response to it changing,
+// returning the cached body, if there is one.
+func (c *Client) revalidate(req *http.Request, path string) []byte {
+	if c.Cache == nil || req.Method != http.MethodGet {
+		return nil
+	}
+	etag, body, ok := c.Cache.Get(path)
+	if !ok || etag == "" {
+		return nil
+	}
+	req.Header.Set("If-None-Match", etag)
+	return body
+}
+
+// useCache answers resp from the cache: with the cached body if the API
+// says it's still current, or by caching the new body as it's read.
+func (c *Client) useCache(resp *http.Response, path string, cached []byte) {
+	if c.Cache == nil || resp.Request.Method != http.MethodGet {
+		return
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Body = io.NopCloser(bytes.NewReader(cached))
+		resp.ContentLength = int64(len(cached))
+		return
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" || resp.StatusCode != http.StatusOK {
+		return
+	}
+	resp.Body = &cachingBody{ReadCloser: resp.Body, done: func(body []byte) {
+		c.Cache.Set(path, etag, body)
+	}}
+}
+
+// cachingBody hands a response body to done once it has all been read.
+type cachingBody struct {
+	io.ReadCloser
+	buf  bytes.Buffer
+	done func([]byte)
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF && b.done != nil {
+		b.done(b.buf.Bytes())
+		b.done = nil
+	}
+	return n, err
+}
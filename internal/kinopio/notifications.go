@@ -0,0 +1,32 @@
+package kinopio
+
+import (
+	"context"
+	"time"
+)
+
+// Notification tells the user about something another user did, like
+// adding a card to a shared space or inviting them to one.
+type Notification struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"` // e.g. "addCard" or "inviteUserToSpace"
+	IsRead    bool      `json:"isRead"`
+	CreatedAt time.Time `json:"createdAt"`
+	SpaceID   string    `json:"spaceId"`
+	CardID    string    `json:"cardId"`
+	User      User      `json:"user"`  // Who did it
+	Space     Space     `json:"space"` // Just its name and URL
+	Card      Card      `json:"card"`  // Just its name
+}
+
+// Notifications fetches the user's notifications, newest first.
+func (c *Client) Notifications(ctx context.Context) ([]Notification, error) {
+	var notifications []Notification
+	err := c.do(ctx, "GET", "/notifications", nil, &notifications, "fetch notifications")
+	return notifications, err
+}
+
+// MarkNotificationsRead marks all the user's notifications as read.
+func (c *Client) MarkNotificationsRead(ctx context.Context) error {
+	return c.do(ctx, "POST", "/notifications/mark-all-as-read", nil, nil, "mark notifications read")
+}
@@ -0,0 +1,75 @@
+package kinopio
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRateLimit is how many requests a second NewClient's clients
+	// make at most, keeping under the API's limit.
+	DefaultRateLimit = 10
+	// DefaultRateBurst is how many requests they can make at once.
+	DefaultRateBurst = 5
+)
+
+// Limiter spaces out requests to stay under the API's rate limit, and
+// holds them all back when the API says the limit has been hit anyway. It
+// can be shared between clients.
+type Limiter struct {
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// NewLimiter returns a limiter that allows perSecond requests a second,
+// up to burst at once.
+func NewLimiter(perSecond float64, burst int) *Limiter {
+	return &Limiter{limiter: rate.NewLimiter(rate.Limit(perSecond), burst)}
+}
+
+// Wait blocks until a request can be made, or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	paused := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+	if paused > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(paused):
+		}
+	}
+	return l.limiter.Wait(ctx)
+}
+
+// Pause holds back every request for d.
+func (l *Limiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// retryAfter reads a response's Retry-After header, which is either a
+// number of seconds or a date. It returns zero if there isn't one.
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -0,0 +1,53 @@
+package kinopio
+
+import "context"
+
+// Spaces fetches the user's spaces, without their cards.
+func (c *Client) Spaces(ctx context.Context) ([]Space, error) {
+	var spaces []Space
+	err := c.do(ctx, "GET", "/user/spaces", nil, &spaces, "fetch spaces")
+	return spaces, err
+}
+
+// Space fetches a space with its cards, boxes and connections.
+func (c *Client) Space(ctx context.Context, spaceID string) (Space, error) {
+	var space Space
+	err := c.do(ctx, "GET", "/space/"+spaceID, nil, &space, "fetch space details")
+	return space, err
+}
+
+// ExploreSpaces fetches the public spaces featured in Kinopio's Explore,
+// without their cards.
+func (c *Client) ExploreSpaces(ctx context.Context) ([]Space, error) {
+	var spaces []Space
+	err := c.do(ctx, "GET", "/space/explore-spaces", nil, &spaces, "fetch explore spaces")
+	return spaces, err
+}
+
+// CreateSpace creates an empty space with the given ID and name, and
+// returns it as saved.
+func (c *Client) CreateSpace(ctx context.Context, id, name string) (Space, error) {
+	space := Space{ID: id, Name: name}
+	body := map[string]interface{}{"id": id, "name": name}
+	err := c.do(ctx, "POST", "/space", body, &space, "create space")
+	return space, err
+}
+
+// UpdateSpace PATCHes the given fields of a space. fields must include the
+// space's id.
+func (c *Client) UpdateSpace(ctx context.Context, fields map[string]interface{}) error {
+	return c.do(ctx, "PATCH", "/space", fields, nil, "update space")
+}
+
+// RemoveSpace moves a space to the user's removed spaces, where it can be
+// restored from.
+func (c *Client) RemoveSpace(ctx context.Context, spaceID string) error {
+	return c.do(ctx, "DELETE", "/space", map[string]interface{}{"id": spaceID}, nil, "remove space")
+}
+
+// Inbox fetches the user's inbox space with its cards.
+func (c *Client) Inbox(ctx context.Context) (Space, error) {
+	var space Space
+	err := c.do(ctx, "GET", "/space/inbox", nil, &space, "fetch inbox")
+	return space, err
+}
@@ -0,0 +1,108 @@
+package kinopio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SpaceProgress reports how far a streamed space has loaded.
+type SpaceProgress struct {
+	Cards     []Card // The cards decoded since the last report
+	BytesRead int64
+	Total     int64 // The response size, or -1 if the server didn't say
+}
+
+// StreamSpace fetches a space like Space does, but decodes its cards as
+// they arrive and reports them in chunks of up to chunkSize, so a large
+// space can be shown while it loads. The returned space has all its cards.
+func (c *Client) StreamSpace(ctx context.Context, spaceID string, chunkSize int, progress func(SpaceProgress)) (Space, error) {
+	if c.APIKey == "" {
+		return Space{}, ErrNoAPIKey
+	}
+	resp, err := c.send(ctx, "GET", "/space/"+spaceID, nil, "fetch space details")
+	if err != nil {
+		return Space{}, err
+	}
+	defer resp.Body.Close()
+
+	body := &countingReader{r: resp.Body}
+	report := func(cards []Card) {
+		progress(SpaceProgress{Cards: cards, BytesRead: body.n, Total: resp.ContentLength})
+	}
+	dec := json.NewDecoder(body)
+	fail := func(err error) (Space, error) {
+		return Space{}, fmt.Errorf("error unmarshaling response: %v", err)
+	}
+
+	// Decode everything but the cards as raw fields, then the space from
+	// those once the cards are done.
+	if _, err := dec.Token(); err != nil {
+		return fail(err)
+	}
+	fields := map[string]json.RawMessage{}
+	var cards []Card
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		name, _ := key.(string)
+		if name != "cards" {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return fail(err)
+			}
+			fields[name] = raw
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil {
+			return fail(err)
+		} else if tok == nil {
+			continue // "cards": null
+		}
+		chunkStart := len(cards)
+		for dec.More() {
+			var card Card
+			if err := dec.Decode(&card); err != nil {
+				return fail(err)
+			}
+			cards = append(cards, card)
+			if len(cards)-chunkStart >= chunkSize {
+				report(cards[chunkStart:len(cards):len(cards)])
+				chunkStart = len(cards)
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return fail(err)
+		}
+		if chunkStart < len(cards) {
+			report(cards[chunkStart:len(cards):len(cards)])
+		}
+	}
+
+	var space Space
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fail(err)
+	}
+	if err := json.Unmarshal(data, &space); err != nil {
+		return fail(err)
+	}
+	space.Cards = cards
+	return space, nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,21 @@
+package kinopio
+
+import (
+	"context"
+	"net/url"
+)
+
+// UserTags fetches the tags used across all of the user's spaces.
+func (c *Client) UserTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	err := c.do(ctx, "GET", "/user/tags", nil, &tags, "fetch tags")
+	return tags, err
+}
+
+// CardsByTag fetches the cards tagged with name in any of the user's
+// spaces. Each card's SpaceID says where it is.
+func (c *Client) CardsByTag(ctx context.Context, name string) ([]Card, error) {
+	var cards []Card
+	err := c.do(ctx, "GET", "/card/by-tag-name/"+url.PathEscape(name), nil, &cards, "fetch tagged cards")
+	return cards, err
+}
@@ -0,0 +1,95 @@
+package kinopio
+
+import "time"
+
+// Card is a card on a space's canvas.
+type Card struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	X                int       `json:"x"`
+	Y                int       `json:"y"`
+	Z                int       `json:"z"`
+	Width            int       `json:"width"`
+	Height           int       `json:"height"`
+	BackgroundColor  string    `json:"backgroundColor"`
+	FrameID          int       `json:"frameId"`
+	IsComment        bool      `json:"isComment"`
+	IsLocked         bool      `json:"isLocked"`
+	CounterValue     int       `json:"counterValue"`
+	CounterIsVisible bool      `json:"counterIsVisible"`
+	URLPreviewURL    string    `json:"urlPreviewUrl"`
+	UserID           string    `json:"userId"`
+	SpaceID          string    `json:"spaceId"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+	NameUpdatedAt    time.Time `json:"nameUpdatedAt"`
+}
+
+// Box is a labelled area of a space's canvas that groups the cards in it.
+type Box struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	X            int    `json:"x"`
+	Y            int    `json:"y"`
+	ResizeWidth  int    `json:"resizeWidth"`
+	ResizeHeight int    `json:"resizeHeight"`
+	Color        string `json:"color"`
+}
+
+// Contains reports whether the card's top-left corner sits inside the box.
+func (b Box) Contains(c Card) bool {
+	return c.X >= b.X && c.X < b.X+b.ResizeWidth &&
+		c.Y >= b.Y && c.Y < b.Y+b.ResizeHeight
+}
+
+// Connection is a line between two cards.
+type Connection struct {
+	ID               string `json:"id"`
+	ConnectionTypeID string `json:"connectionTypeId"`
+	StartItemID      string `json:"startItemId"`
+	EndItemID        string `json:"endItemId"`
+	Label            string `json:"label"`
+}
+
+// ConnectionType is a named, colored kind of connection.
+type ConnectionType struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// User is a Kinopio user.
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Space is a canvas of cards. Lists of spaces come without their cards,
+// boxes and connections.
+type Space struct {
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Url             string           `json:"url"`
+	Privacy         string           `json:"privacy"`
+	IsTemplate      bool             `json:"isTemplate"`
+	CollaboratorKey string           `json:"collaboratorKey"` // Lets an invite link make someone a collaborator
+	UpdatedAt       time.Time        `json:"updatedAt"`
+	Users           []User           `json:"users"`
+	Collaborators   []User           `json:"collaborators"`
+	Cards           []Card           `json:"cards"`
+	Boxes           []Box            `json:"boxes"`
+	Connections     []Connection     `json:"connections"`
+	ConnectionTypes []ConnectionType `json:"connectionTypes"`
+	Tags            []Tag            `json:"tags"`
+}
+
+// Tag is a [[tag]] used in a card. Spaces list one Tag per tagged card;
+// the user's tags list each name once.
+type Tag struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Color   string `json:"color"`
+	CardID  string `json:"cardId"`
+	SpaceID string `json:"spaceId"`
+}
@@ -0,0 +1,75 @@
+package kinopio
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Account is the signed-in user's own profile and settings, which only
+// they can see.
+type Account struct {
+	User
+	Email                      string    `json:"email"`
+	IsUpgraded                 bool      `json:"isUpgraded"`
+	CardsCreatedCount          int       `json:"cardsCreatedCount"`
+	CreatedAt                  time.Time `json:"createdAt"`
+	Description                string    `json:"description"`
+	Website                    string    `json:"website"`
+	Theme                      string    `json:"theme"`
+	DefaultCardBackgroundColor string    `json:"defaultCardBackgroundColor"`
+	ShouldEmailNotifications   bool      `json:"shouldEmailNotifications"`
+	ShouldEmailWeeklyReview    bool      `json:"shouldEmailWeeklyReview"`
+	ShouldEmailBulletin        bool      `json:"shouldEmailBulletin"`
+}
+
+// CurrentUser fetches the user the API key belongs to.
+func (c *Client) CurrentUser(ctx context.Context) (User, error) {
+	var user User
+	err := c.do(ctx, "GET", "/user", nil, &user, "fetch user")
+	return user, err
+}
+
+// CurrentAccount fetches the API key's user with their private details:
+// email, plan and settings.
+func (c *Client) CurrentAccount(ctx context.Context) (Account, error) {
+	var account Account
+	err := c.do(ctx, "GET", "/user", nil, &account, "fetch account")
+	return account, err
+}
+
+// PublicUser fetches another user's public profile.
+func (c *Client) PublicUser(ctx context.Context, userID string) (User, error) {
+	var user User
+	err := c.do(ctx, "GET", "/user/public/"+userID, nil, &user, "fetch user")
+	return user, err
+}
+
+// FavoriteSpaces fetches the spaces the user has favorited.
+func (c *Client) FavoriteSpaces(ctx context.Context) ([]Space, error) {
+	var spaces []Space
+	err := c.do(ctx, "GET", "/user/favorite-spaces", nil, &spaces, "fetch favorite spaces")
+	return spaces, err
+}
+
+// SetFavoriteSpace adds a space to the user's favorites, or takes it out.
+func (c *Client) SetFavoriteSpace(ctx context.Context, spaceID string, favorite bool) error {
+	body := map[string]interface{}{"spaceId": spaceID, "value": favorite}
+	return c.do(ctx, "PATCH", "/user/favorites", body, nil, "update favorites")
+}
+
+// SignIn signs in with an email address and password, returning the
+// account's API key. It doesn't need an API key itself.
+func (c *Client) SignIn(ctx context.Context, email, password string) (string, error) {
+	body := map[string]interface{}{"email": email, "password": password}
+	var user struct {
+		APIKey string `json:"apiKey"`
+	}
+	if err := c.request(ctx, "POST", "/user/sign-in", body, &user, "sign in"); err != nil {
+		return "", err
+	}
+	if user.APIKey == "" {
+		return "", errors.New("sign in succeeded but no API key was returned")
+	}
+	return user.APIKey, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// journalNameLayout matches how Kinopio names daily journal spaces, e.g.
+// "Friday Oct 16/26".
+const journalNameLayout = "Monday Jan 2/06"
+
+type journalMsg struct {
+	Space Space
+}
+
+func journalName(day time.Time) string {
+	return day.Format(journalNameLayout)
+}
+
+// todaysJournal finds today's journal space, creating it if there isn't
+// one yet, and fetches it with its cards.
+func todaysJournal(ctx context.Context) (Space, error) {
+	name := journalName(time.Now().In(displaySettings.location))
+	spaces, err := api.Spaces(ctx)
+	if err != nil {
+		return Space{}, err
+	}
+	for _, space := range spaces {
+		if space.Name == name {
+			return api.Space(ctx, space.ID)
+		}
+	}
+	return api.CreateSpace(ctx, newID(), name)
+}
+
+// openJournal opens today's journal in the cards view.
+func (m *model) openJournal() tea.Cmd {
+	m.loading = true
+	return tea.Batch(func() tea.Msg {
+		space, err := todaysJournal(programCtx)
+		if err != nil {
+			return err
+		}
+		return journalMsg{Space: space}
+	}, m.spinner.Tick)
+}
+
+func (m *model) journalOpened(space Space) {
+	known := false
+	for _, s := range m.spaces {
+		known = known || s.ID == space.ID
+	}
+	if !known {
+		m.spaces = append([]Space{space}, m.spaces...)
+	}
+	if space.ID != m.selectedSpace.ID {
+		m.selected = map[string]bool{}
+	}
+	m.selectedSpace = space
+	m.boxFilter = nil
+	m.tagFilter = ""
+	m.loading = false
+	m.showCards()
+}
+
+// runJournal starts the TUI on today's journal.
+func runJournal(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: kinopio-tui journal")
+	}
+	return runTUI(true)
+}
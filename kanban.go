@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	kanbanMinColumnWidth = 24
+	kanbanCardPadding    = 20 // Gap between a box's edge and the cards moved into it
+	kanbanBoxHeaderSpace = 40 // Room left for a box's name above its cards
+)
+
+// kanbanColumn is a box and the cards inside it, top to bottom. The
+// column for unboxed cards has no box ID.
+type kanbanColumn struct {
+	box   Box
+	cards []Card
+}
+
+// kanbanBoard shows a space's boxes as side-by-side columns.
+type kanbanBoard struct {
+	columns []kanbanColumn
+	col     int
+	row     int
+}
+
+// newKanbanBoard builds columns for the space's boxes in left-to-right
+// order, with an Unboxed column last if any cards aren't in a box.
+func (m *model) newKanbanBoard() *kanbanBoard {
+	boxes := append([]Box(nil), m.selectedSpace.Boxes...)
+	sort.SliceStable(boxes, func(i, j int) bool {
+		if boxes[i].X != boxes[j].X {
+			return boxes[i].X < boxes[j].X
+		}
+		return boxes[i].Y < boxes[j].Y
+	})
+	byBox := map[string][]Card{}
+	for _, card := range sortCards(m.commentFilter(m.selectedSpace.Cards), sortReading) {
+		box, _ := m.boxForCard(card)
+		byBox[box.ID] = append(byBox[box.ID], card)
+	}
+
+	board := &kanbanBoard{}
+	for _, box := range boxes {
+		board.columns = append(board.columns, kanbanColumn{box: box, cards: byBox[box.ID]})
+	}
+	if unboxed := byBox[""]; len(unboxed) > 0 {
+		board.columns = append(board.columns, kanbanColumn{box: Box{Name: "Unboxed"}, cards: unboxed})
+	}
+	return board
+}
+
+func (b *kanbanBoard) selectedCard() (Card, bool) {
+	if b.col >= len(b.columns) || b.row >= len(b.columns[b.col].cards) {
+		return Card{}, false
+	}
+	return b.columns[b.col].cards[b.row], true
+}
+
+// clampRow keeps the cursor on a card after changing columns.
+func (b *kanbanBoard) clampRow() {
+	b.row = max(0, min(b.row, len(b.columns[b.col].cards)-1))
+}
+
+func (m *model) showKanban() {
+	m.currentView = "kanban"
+	m.kanban = m.newKanbanBoard()
+}
+
+// refreshKanban rebuilds the board after its cards change, keeping the
+// cursor on the same card if it's still there.
+func (m *model) refreshKanban(cardID string) {
+	m.kanban = m.newKanbanBoard()
+	for c, column := range m.kanban.columns {
+		for r, card := range column.cards {
+			if card.ID == cardID {
+				m.kanban.col, m.kanban.row = c, r
+			}
+		}
+	}
+}
+
+// updateKanban handles a key press in the kanban view. It reports false
+// for the global keys that still work on the board, so they fall through.
+func (m *model) updateKanban(msg tea.KeyMsg) (bool, tea.Cmd) {
+	b := m.kanban
+	switch {
+	case key.Matches(msg, keys.Kanban, keys.Back, keys.Cancel):
+		m.kanban = nil
+		return true, m.popView()
+	case key.Matches(msg, keys.Quit, keys.Help, keys.Palette, keys.SwitchSpace, keys.Notifications, keys.HistoryBack, keys.HistoryForward, keys.Inbox, keys.Journal, keys.Undo, keys.Redo, keys.Refresh):
+		return false, nil
+	}
+	if len(b.columns) == 0 {
+		return true, nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Left):
+		if b.col > 0 {
+			b.col--
+			b.clampRow()
+		}
+	case key.Matches(msg, keys.Right):
+		if b.col < len(b.columns)-1 {
+			b.col++
+			b.clampRow()
+		}
+	case key.Matches(msg, keys.Up):
+		if b.row > 0 {
+			b.row--
+		}
+	case key.Matches(msg, keys.Down):
+		if b.row < len(b.columns[b.col].cards)-1 {
+			b.row++
+		}
+	case key.Matches(msg, keys.MoveLeft):
+		return true, m.moveKanbanCard(-1)
+	case key.Matches(msg, keys.MoveRight):
+		return true, m.moveKanbanCard(1)
+	case key.Matches(msg, keys.Enter):
+		if card, ok := b.selectedCard(); ok {
+			m.selectedCard = card
+			m.currentView = "cardDetails"
+			return true, m.showCardDetails()
+		}
+	}
+	return true, nil
+}
+
+// moveKanbanCard moves the selected card into the box one column to the
+// left or right, below the cards already there. The box grows if the card
+// wouldn't fit.
+func (m *model) moveKanbanCard(step int) tea.Cmd {
+	b := m.kanban
+	card, ok := b.selectedCard()
+	target := b.col + step
+	if !ok || target < 0 || target >= len(b.columns) || b.columns[target].box.ID == "" {
+		return nil
+	}
+	box := b.columns[target].box
+
+	card.X = box.X + kanbanCardPadding
+	card.Y = box.Y + kanbanBoxHeaderSpace
+	for _, c := range b.columns[target].cards {
+		card.Y = max(card.Y, c.Y+bulkAddSpacing)
+	}
+	cmds := []tea.Cmd{updateCard(map[string]interface{}{"id": card.ID, "x": card.X, "y": card.Y})}
+
+	if bottom := card.Y + bulkAddSpacing - box.Y; bottom > box.ResizeHeight {
+		box.ResizeHeight = bottom
+		for i, existing := range m.selectedSpace.Boxes {
+			if existing.ID == box.ID {
+				m.selectedSpace.Boxes[i] = box
+			}
+		}
+		cmds = append(cmds, apiCmd(func(ctx context.Context) error {
+			return api.UpdateBox(ctx, map[string]interface{}{"id": box.ID, "resizeHeight": box.ResizeHeight})
+		}))
+	}
+
+	m.recordUpdate("move to box", card)
+	m.replaceCards(card)
+	m.refreshKanban(card.ID)
+	return tea.Batch(cmds...)
+}
+
+func (b *kanbanBoard) View(width, height int) string {
+	if len(b.columns) == 0 {
+		return "This space has no boxes or cards.\n"
+	}
+
+	// Show as many columns as fit, scrolled to keep the cursor in view.
+	visible := max(1, min(len(b.columns), width/kanbanMinColumnWidth))
+	first := min(max(0, b.col-visible/2), len(b.columns)-visible)
+	columnWidth := width/visible - 2
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Title)
+	cardStyle := lipgloss.NewStyle().Padding(0, 1)
+	cursorStyle := cardStyle.Foreground(theme.OnAccent).Background(theme.Accent)
+	cardRows := max(1, height-4)
+
+	var rendered []string
+	for c := first; c < first+visible; c++ {
+		column := b.columns[c]
+		lines := []string{titleStyle.Render(ansi.Truncate(fmt.Sprintf("%s (%d)", column.box.Name, len(column.cards)), columnWidth-2, "…")), ""}
+
+		// Scroll the column so the cursor's card is on screen.
+		start := 0
+		if c == b.col && b.row >= cardRows {
+			start = b.row - cardRows + 1
+		}
+		for r := start; r < len(column.cards) && r < start+cardRows; r++ {
+			style := cardStyle
+			if c == b.col && r == b.row {
+				style = cursorStyle
+			}
+			name := ansi.Truncate(firstLine(column.cards[r].Name), columnWidth-4, "…")
+			lines = append(lines, style.Width(columnWidth-2).Render(name))
+		}
+		if len(column.cards) == 0 {
+			lines = append(lines, dimStyle().Render("(empty)"))
+		}
+
+		border := theme.Border
+		if c == b.col {
+			border = theme.Accent
+		}
+		rendered = append(rendered, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(border).
+			Width(columnWidth).
+			Height(height-2).
+			Render(strings.Join(lines, "\n")))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// The API key's entry in the system keychain: Keychain on macOS, the
+// Secret Service on Linux and Credential Manager on Windows.
+const (
+	keyringService = "kinopio-tui"
+	keyringUser    = "api-key"
+)
+
+// Ways of storing the API key, set with auth in the config file or
+// --auth.
+const (
+	authConfig  = "config"  // In the config file or KINOPIO_API_KEY
+	authKeyring = "keyring" // In the system keychain
+)
+
+// keyringAPIKey reads the API key from the keychain. A missing entry is an
+// empty key, so first-run setup can ask for one.
+func keyringAPIKey() (string, error) {
+	key, err := keyring.Get(keyringService, keyringUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading API key from the keychain: %v", err)
+	}
+	return key, nil
+}
+
+func saveKeyringAPIKey(key string) error {
+	if err := keyring.Set(keyringService, keyringUser, key); err != nil {
+		return fmt.Errorf("error saving API key to the keychain: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyMap holds every keybinding. Many keys do different things in
+// different views, so bindings are named for what their key generally
+// means, and helpGroups describes what each does in the current view.
+type keyMap struct {
+	Quit           key.Binding
+	Help           key.Binding
+	Palette        key.Binding
+	SwitchSpace    key.Binding
+	Notifications  key.Binding
+	Search         key.Binding
+	Find           key.Binding
+	HistoryBack    key.Binding
+	HistoryForward key.Binding
+	Refresh        key.Binding
+	Inbox          key.Binding
+	Journal        key.Binding
+	Undo           key.Binding
+	Redo           key.Binding
+
+	Enter     key.Binding
+	Back      key.Binding
+	Cancel    key.Binding
+	Backspace key.Binding
+
+	New       key.Binding
+	NewRandom key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	Warm      key.Binding
+	Group     key.Binding
+	Tags      key.Binding
+
+	JumpTo         key.Binding
+	NumberCards    key.Binding
+	Sort           key.Binding
+	Comment        key.Binding
+	HideComments   key.Binding
+	Task           key.Binding
+	OpenTasks      key.Binding
+	Pin            key.Binding
+	ShowUpdated    key.Binding
+	Select         key.Binding
+	SelectMatching key.Binding
+	BulkActions    key.Binding
+	BulkAdd        key.Binding
+	Move           key.Binding
+	Copy           key.Binding
+	Delete         key.Binding
+	Edit           key.Binding
+	Resize         key.Binding
+	Advanced       key.Binding
+	Nudge          key.Binding
+	NudgeStep      key.Binding
+	Archive        key.Binding
+	CheckLinks     key.Binding
+	Duplicates     key.Binding
+	Merge          key.Binding
+	ImportMarks    key.Binding
+	Arrange        key.Binding
+	Present        key.Binding
+	Kanban         key.Binding
+	Canvas         key.Binding
+	ExportGraph    key.Binding
+	Split          key.Binding
+	Color          key.Binding
+	Favorite       key.Binding
+	Explore        key.Binding
+	Account        key.Binding
+	Open           key.Binding
+	Yank           key.Binding
+	OpenInKinopio  key.Binding
+	RawText        key.Binding
+	ExternalEdit   key.Binding
+	EditSpace      key.Binding
+	GrowList       key.Binding
+	ShrinkList     key.Binding
+
+	// The kanban board and the canvas
+	Left      key.Binding
+	Right     key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+	MoveUp    key.Binding
+	MoveDown  key.Binding
+	NextCard  key.Binding
+	PrevCard  key.Binding
+
+	// Presentations
+	NextSlide  key.Binding
+	PrevSlide  key.Binding
+	FirstSlide key.Binding
+	LastSlide  key.Binding
+	SlideOrder key.Binding
+}
+
+var keys = keyMap{
+	Quit:           key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	Help:           key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "all keys")),
+	Palette:        key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("ctrl+p", "commands")),
+	SwitchSpace:    key.NewBinding(key.WithKeys("ctrl+o", "ctrl+k"), key.WithHelp("ctrl+o", "switch space")),
+	Notifications:  key.NewBinding(key.WithKeys("ctrl+n"), key.WithHelp("ctrl+n", "notifications")),
+	Search:         key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "search")),
+	Find:           key.NewBinding(key.WithKeys("ctrl+f"), key.WithHelp("ctrl+f", "find")),
+	HistoryBack:    key.NewBinding(key.WithKeys("alt+left", "alt+h"), key.WithHelp("alt+←", "previous view")),
+	HistoryForward: key.NewBinding(key.WithKeys("alt+right", "alt+l"), key.WithHelp("alt+→", "next view")),
+	Refresh:        key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	Inbox:          key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "add to inbox")),
+	Journal:        key.NewBinding(key.WithKeys("ctrl+j"), key.WithHelp("ctrl+j", "today's journal")),
+	Undo:           key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo")),
+	Redo:           key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "redo")),
+
+	Enter:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open")),
+	Back:      key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "back")),
+	Cancel:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	Backspace: key.NewBinding(key.WithKeys("backspace")),
+
+	New:       key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new")),
+	NewRandom: key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "new, randomly named")),
+	NextMatch: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+	PrevMatch: key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "previous match")),
+	Warm:      key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "keep warm")),
+	Group:     key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "group")),
+	Tags:      key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "tags")),
+
+	JumpTo:         key.NewBinding(key.WithKeys("0", "1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("0-9", "jump to card number")),
+	NumberCards:    key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "number cards")),
+	Sort:           key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort")),
+	Comment:        key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "toggle comment")),
+	HideComments:   key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "hide comments")),
+	Task:           key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "check task")),
+	OpenTasks:      key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "open tasks only")),
+	Pin:            key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pin")),
+	ShowUpdated:    key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "updated times")),
+	Select:         key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "select")),
+	SelectMatching: key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "select matching")),
+	BulkActions:    key.NewBinding(key.WithKeys("B"), key.WithHelp("B", "bulk actions")),
+	BulkAdd:        key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "add many cards")),
+	Move:           key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "move to another space")),
+	Copy:           key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "copy to another space")),
+	Delete:         key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+	Edit:           key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit")),
+	Resize:         key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "resize")),
+	Advanced:       key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "advanced fields")),
+	Nudge:          key.NewBinding(key.WithKeys("shift+up", "shift+down", "shift+left", "shift+right"), key.WithHelp("shift+arrows", "move card")),
+	NudgeStep:      key.NewBinding(key.WithKeys("[", "]"), key.WithHelp("[/]", "change step")),
+	Archive:        key.NewBinding(key.WithKeys("W"), key.WithHelp("W", "archive links")),
+	CheckLinks:     key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "check links")),
+	Duplicates:     key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "find duplicates")),
+	Merge:          key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "merge")),
+	ImportMarks:    key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "import bookmarks")),
+	Arrange:        key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "arrange in a grid")),
+	Present:        key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "present")),
+	Kanban:         key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "kanban")),
+	Canvas:         key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "canvas")),
+	ExportGraph:    key.NewBinding(key.WithKeys("G"), key.WithHelp("G", "export graph")),
+	Color:          key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "fill color")),
+	Favorite:       key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "favorite")),
+	Explore:        key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "explore public spaces")),
+	Account:        key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "account")),
+	Yank:           key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy")),
+	Open:           key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open")),
+	OpenInKinopio:  key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "open in Kinopio")),
+	RawText:        key.NewBinding(key.WithKeys("`"), key.WithHelp("`", "raw text")),
+	ExternalEdit:   key.NewBinding(key.WithKeys("ctrl+e"), key.WithHelp("ctrl+e", "edit in $EDITOR")),
+	EditSpace:      key.NewBinding(key.WithKeys("alt+e"), key.WithHelp("alt+e", "edit space as text")),
+	Split:          key.NewBinding(key.WithKeys("|"), key.WithHelp("|", "preview pane")),
+	GrowList:       key.NewBinding(key.WithKeys("ctrl+right"), key.WithHelp("ctrl+→", "widen list")),
+	ShrinkList:     key.NewBinding(key.WithKeys("ctrl+left"), key.WithHelp("ctrl+←", "narrow list")),
+
+	Left:      key.NewBinding(key.WithKeys("h", "left"), key.WithHelp("h", "left")),
+	Right:     key.NewBinding(key.WithKeys("l", "right"), key.WithHelp("l", "right")),
+	Up:        key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("k", "up")),
+	Down:      key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("j", "down")),
+	MoveLeft:  key.NewBinding(key.WithKeys("H", "shift+left"), key.WithHelp("H", "move left")),
+	MoveRight: key.NewBinding(key.WithKeys("L", "shift+right"), key.WithHelp("L", "move right")),
+	MoveUp:    key.NewBinding(key.WithKeys("K", "shift+up"), key.WithHelp("K", "move up")),
+	MoveDown:  key.NewBinding(key.WithKeys("J", "shift+down"), key.WithHelp("J", "move down")),
+	NextCard:  key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next card")),
+	PrevCard:  key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous card")),
+
+	NextSlide:  key.NewBinding(key.WithKeys("right", "l", "n", " ", "pgdown", "enter"), key.WithHelp("→", "next")),
+	PrevSlide:  key.NewBinding(key.WithKeys("left", "h", "p", "pgup", "backspace"), key.WithHelp("←", "previous")),
+	FirstSlide: key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g", "first")),
+	LastSlide:  key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G", "last")),
+	SlideOrder: key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "order")),
+}
+
+// helpColumns is how many groups of keys the help overlay puts side by
+// side.
+const helpColumns = 4
+
+// describe returns a copy of b with help saying what it does in a
+// particular view.
+func describe(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// helpGroups lists the keys that work in the current view, most used
+// first. The first group is shown in the help line, and all of them in
+// the help overlay. Keys that would change a read-only space are left
+// out.
+func (m *model) helpGroups() [][]key.Binding {
+	groups := m.viewHelpGroups()
+	for i, group := range groups {
+		groups[i] = slices.DeleteFunc(slices.Clone(group), func(b key.Binding) bool {
+			return m.editsReadOnly(b.Keys()[0])
+		})
+	}
+	return groups
+}
+
+func (m *model) viewHelpGroups() [][]key.Binding {
+	nav := []key.Binding{m.list.KeyMap.CursorUp, m.list.KeyMap.CursorDown, m.list.KeyMap.Filter}
+	global := []key.Binding{keys.SwitchSpace, keys.Palette, keys.Notifications, keys.Inbox, keys.Journal}
+	split := []key.Binding{keys.Split}
+	if m.splitActive() && !m.previewBelow() {
+		split = append(split, keys.ShrinkList, keys.GrowList)
+	}
+	if m.splitActive() && m.currentView == "cards" {
+		split = append(split, keys.RawText)
+	}
+	history := []key.Binding{keys.HistoryBack, keys.HistoryForward, keys.Quit}
+	if (m.currentView == "list" || m.inSpace()) && m.currentView != "removed" {
+		history = append([]key.Binding{keys.Refresh}, history...)
+	}
+	back := keys.Back
+
+	switch m.currentView {
+	case "list":
+		return [][]key.Binding{
+			{describe(keys.Enter, "open space"), describe(keys.Search, "search all spaces"), m.list.KeyMap.Filter, describe(keys.New, "new space"), keys.Help},
+			{describe(keys.Open, "open in Kinopio"), describe(keys.Yank, "copy URL"), describe(keys.NewRandom, "new, randomly named"), keys.Warm, keys.Favorite, keys.Explore, keys.Account, describe(keys.Sort, "sort"), describe(keys.Group, "group by name or kind"), keys.Tags},
+			split,
+			global,
+			history,
+		}
+	case "cards":
+		first := []key.Binding{describe(keys.Enter, "details"), keys.Find, m.list.KeyMap.Filter, describe(keys.New, "new card"), describe(keys.Delete, "remove"), back, keys.Help}
+		if m.find != nil {
+			first = []key.Binding{keys.NextMatch, keys.PrevMatch, describe(keys.Find, "change search"), describe(keys.Cancel, "clear search"), keys.Help}
+		}
+		return [][]key.Binding{
+			first,
+			{keys.Sort, describe(keys.Group, "group by box"), keys.NumberCards, keys.JumpTo, keys.Comment, keys.HideComments, keys.Task, keys.OpenTasks, keys.Pin, keys.ShowUpdated, keys.Color, keys.ExternalEdit, keys.OpenInKinopio, describe(keys.Yank, "copy text, link or space URL")},
+			{keys.Select, keys.SelectMatching, keys.BulkActions, keys.Move, keys.Copy, keys.BulkAdd, keys.EditSpace, keys.ImportMarks, keys.Duplicates, keys.CheckLinks, keys.Archive, keys.Arrange},
+			{keys.Kanban, keys.Canvas, keys.Present, describe(keys.Open, "open space in Kinopio"), keys.Undo, keys.Redo},
+			split,
+			global,
+			history,
+		}
+	case "cardDetails":
+		return [][]key.Binding{
+			{describe(keys.Edit, "edit name"), keys.ExternalEdit, describe(keys.New, "add comment"), describe(keys.Open, "open link"), describe(keys.Nudge, fmt.Sprintf("move %dpx", m.nudgeStep)), keys.NudgeStep, back, keys.Help},
+			{keys.OpenInKinopio, describe(keys.Yank, "copy text, link or space URL"), keys.Advanced, keys.RawText, keys.Comment, keys.Task, keys.Color, keys.Archive, keys.Move, keys.Copy, keys.Undo, keys.Redo},
+			global,
+			history,
+		}
+	case "kanban":
+		return [][]key.Binding{
+			{keys.Left, keys.Right, keys.Up, keys.Down, describe(keys.Enter, "details"), describe(keys.Kanban, "back to list"), keys.Help},
+			{describe(keys.MoveLeft, "move card to the left"), describe(keys.MoveRight, "move card to the right")},
+			global,
+			history,
+		}
+	case "canvas":
+		return [][]key.Binding{
+			{keys.Left, keys.Right, keys.Up, keys.Down, keys.NextCard, describe(keys.Enter, "open card"), describe(keys.Canvas, "back to list"), keys.Help},
+			{describe(keys.MoveLeft, "faster left"), describe(keys.MoveRight, "faster right"), describe(keys.MoveUp, "faster up"), describe(keys.MoveDown, "faster down"), keys.PrevCard},
+			global,
+			history,
+		}
+	}
+
+	var first []key.Binding
+	switch m.currentView {
+	case "details":
+		first = []key.Binding{keys.Enter, describe(keys.Open, "open in Kinopio"), describe(keys.Yank, "copy URL"), keys.ExportGraph, back, keys.Undo, keys.Redo}
+	case "boxes":
+		first = []key.Binding{describe(keys.Enter, "view cards"), describe(keys.New, "new box"), describe(keys.Edit, "rename"), keys.Resize, keys.Delete, back}
+	case "connections":
+		first = []key.Binding{describe(keys.Edit, "edit label"), keys.ExportGraph, back}
+	case "graph":
+		first = []key.Binding{describe(keys.Enter, "open card"), keys.ExportGraph, back}
+	case "links", "tagCards":
+		first = []key.Binding{describe(keys.Enter, "open card"), back}
+	case "bulkActions":
+		first = []key.Binding{describe(keys.Enter, "apply to selected cards"), back}
+	case "removed":
+		first = []key.Binding{describe(keys.Refresh, "restore card"), back}
+	case "duplicates":
+		first = []key.Binding{describe(keys.Merge, "merge into the older card"), describe(keys.Delete, "delete the newer card"), back}
+	case "tags", "allTags":
+		first = []key.Binding{describe(keys.Enter, "show cards"), back}
+	case "account":
+		first = []key.Binding{back}
+	default:
+		first = []key.Binding{keys.Enter, back}
+	}
+	return [][]key.Binding{append(first, keys.Help), nav, global, history}
+}
+
+// helpLine is the one-line help under a view.
+func (m *model) helpLine() string {
+	h := help.New()
+	h.Width = m.width
+	return "\n" + h.ShortHelpView(m.helpGroups()[0])
+}
+
+// updateHelp closes the help overlay on ? or esc, and ignores other keys.
+func (m *model) updateHelp(msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, keys.Help, keys.Cancel) {
+		m.showHelp = false
+	}
+	return nil
+}
+
+// helpView lists every key for the current view, with the groups of keys
+// side by side in rows.
+func (m *model) helpView() string {
+	h := help.New()
+	h.Width = m.width - 8
+	groups := m.helpGroups()
+	var rows []string
+	for i := 0; i < len(groups); i += helpColumns {
+		rows = append(rows, h.FullHelpView(groups[i:min(i+helpColumns, len(groups))]))
+	}
+	rows = append(rows, dimStyle().Render("? or esc to close"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n\n"))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Size of a grid cell, in canvas pixels, when arranging cards.
+const (
+	gridCellWidth  = 240
+	gridCellHeight = 100
+)
+
+// Layout of imported cards: one column per group, boxed when the group
+// has a name.
+const (
+	importColumnWidth = 320
+	importBoxPadding  = 60
+	importOriginX     = 100
+	importOriginY     = 100
+)
+
+// column is a group of card texts for layoutColumns.
+type column struct {
+	name  string
+	cards []string
+}
+
+// layoutColumns creates a vertical stack of cards for each column, side
+// by side. Named columns are wrapped in a box with that name.
+func layoutColumns(columns []column) ([]Box, []Card) {
+	var (
+		boxes []Box
+		cards []Card
+	)
+	for col, c := range columns {
+		x := importOriginX + col*importColumnWidth
+		y := importOriginY
+		if c.name != "" {
+			boxes = append(boxes, Box{
+				ID:           newID(),
+				Name:         c.name,
+				X:            x,
+				Y:            y,
+				ResizeWidth:  importColumnWidth - 20,
+				ResizeHeight: importBoxPadding + len(c.cards)*bulkAddSpacing,
+			})
+			y += importBoxPadding
+		}
+		for i, name := range c.cards {
+			cards = append(cards, Card{ID: newID(), Name: name, X: x + 10, Y: y + i*bulkAddSpacing})
+		}
+	}
+	return boxes, cards
+}
+
+// nextCardPosition is where a new card goes so it doesn't cover existing
+// ones: at the left edge, below the lowest card.
+func nextCardPosition(cards []Card) (int, int) {
+	if len(cards) == 0 {
+		return importOriginX, importOriginY
+	}
+	y := cards[0].Y
+	for _, card := range cards {
+		y = max(y, card.Y)
+	}
+	return importOriginX, y + bulkAddSpacing
+}
+
+// arrangeGrid lays cards out in a roughly square grid, keeping their
+// reading order and anchoring the grid at their top-left-most position.
+// It returns moved copies of the cards.
+func arrangeGrid(cards []Card) []Card {
+	if len(cards) == 0 {
+		return nil
+	}
+	sorted := sortCards(cards, sortReading)
+	originX, originY := sorted[0].X, sorted[0].Y
+	for _, card := range sorted {
+		originX = min(originX, card.X)
+		originY = min(originY, card.Y)
+	}
+
+	columns := int(math.Ceil(math.Sqrt(float64(len(sorted)))))
+	for i := range sorted {
+		sorted[i].X = originX + (i%columns)*gridCellWidth
+		sorted[i].Y = originY + (i/columns)*gridCellHeight
+	}
+	return sorted
+}
+
+func (m *model) unboxedCards() []Card {
+	var cards []Card
+	for _, card := range m.selectedSpace.Cards {
+		if _, ok := m.boxForCard(card); !ok {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// confirmArrangeGrid asks before arranging the selected cards (or all
+// unboxed cards, if nothing is selected) into a grid, since it overwrites
+// their positions.
+func (m *model) confirmArrangeGrid() {
+	cards, noun := m.selectedCards(), "selected card"
+	if len(cards) == 0 {
+		cards, noun = m.unboxedCards(), "unboxed card"
+	}
+	if len(cards) == 0 {
+		return
+	}
+	message := fmt.Sprintf("Arrange %s into a grid? Their current positions will be overwritten.", plural(len(cards), noun))
+	m.askConfirm(message, false, func() tea.Cmd {
+		moved := arrangeGrid(cards)
+		m.recordUpdate("grid layout", moved...)
+		m.replaceCards(moved...)
+		updates := make([]map[string]interface{}, len(moved))
+		for i, card := range moved {
+			updates[i] = map[string]interface{}{"id": card.ID, "x": card.X, "y": card.Y}
+		}
+		return updateCards(updates)
+	})
+}
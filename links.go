@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	linkCheckWorkers = 8
+	linkCheckTimeout = 10 * time.Second
+)
+
+// linkCheck is the result of checking one URL found in a card.
+type linkCheck struct {
+	url    string
+	card   Card
+	status string // HTTP status or error, for dead links
+	dead   bool
+}
+
+type linkCheckDoneMsg struct {
+	results []linkCheck
+}
+
+// checkLinks checks every URL in cards concurrently and reports the dead
+// ones.
+func checkLinks(cards []Card) tea.Cmd {
+	var checks []linkCheck
+	for _, card := range cards {
+		for _, url := range cardURLs(card.Name) {
+			checks = append(checks, linkCheck{url: url, card: card})
+		}
+	}
+	return func() tea.Msg {
+		client := &http.Client{Timeout: linkCheckTimeout}
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < linkCheckWorkers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					checks[i].status, checks[i].dead = checkLink(client, checks[i].url)
+				}
+			}()
+		}
+		for i := range checks {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+
+		var dead []linkCheck
+		for _, check := range checks {
+			if check.dead {
+				dead = append(dead, check)
+			}
+		}
+		return linkCheckDoneMsg{results: dead}
+	}
+}
+
+// checkLink sends a HEAD request, falling back to GET for servers that
+// don't support HEAD.
+func checkLink(client *http.Client, url string) (string, bool) {
+	resp, err := client.Head(url)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp.Body.Close()
+		resp, err = client.Get(url)
+	}
+	if err != nil {
+		return err.Error(), true
+	}
+	resp.Body.Close()
+	return resp.Status, resp.StatusCode >= 400
+}
+
+func (m *model) startLinkCheck() tea.Cmd {
+	m.loading = true
+	return tea.Batch(checkLinks(m.selectedSpace.Cards), m.spinner.Tick)
+}
+
+func (m *model) showDeadLinks(results []linkCheck) {
+	m.currentView = "links"
+	m.list.Title = fmt.Sprintf("%s → Dead links (%d)", m.selectedSpace.Name, len(results))
+	items := make([]list.Item, len(results))
+	for i, result := range results {
+		items[i] = linkListItem{result}
+	}
+	m.setItems(items)
+}
+
+type linkListItem struct {
+	check linkCheck
+}
+
+func (i linkListItem) FilterValue() string { return i.check.url }
+func (i linkListItem) Title() string       { return i.check.url }
+func (i linkListItem) Description() string {
+	return fmt.Sprintf("%s · in %q", i.check.status, firstLine(i.check.card.Name))
+}
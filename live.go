@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
+)
+
+const (
+	liveRetryDelay = 10 * time.Second // Before reconnecting a dropped connection
+	liveHighlight  = 5 * time.Second  // How long changed cards stay marked
+)
+
+// liveClientID identifies this run to the broadcast server, which doesn't
+// send a client's own changes back to it.
+var liveClientID = newID()
+
+type liveJoinedMsg struct {
+	spaceID   string
+	broadcast *kinopio.Broadcast
+}
+
+type liveMsg struct {
+	spaceID string
+	msg     kinopio.BroadcastMessage
+}
+
+// liveClosedMsg reports that the connection to a space dropped, or
+// couldn't be made.
+type liveClosedMsg struct {
+	spaceID string
+}
+
+type liveRetryMsg struct {
+	spaceID string
+}
+
+type liveFadeMsg struct{}
+
+func joinLive(spaceID string) tea.Cmd {
+	return func() tea.Msg {
+		b, err := api.JoinSpace(programCtx, spaceID, liveClientID)
+		if err != nil {
+			return liveClosedMsg{spaceID}
+		}
+		return liveJoinedMsg{spaceID: spaceID, broadcast: b}
+	}
+}
+
+func listenLive(spaceID string, b *kinopio.Broadcast) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := b.Next()
+		if err != nil {
+			return liveClosedMsg{spaceID}
+		}
+		return liveMsg{spaceID: spaceID, msg: msg}
+	}
+}
+
+// followSpace starts listening for live changes to a space, leaving the
+// one followed before.
+func (m *model) followSpace(spaceID string) tea.Cmd {
+	if spaceID == m.liveSpaceID {
+		return nil
+	}
+	if m.live != nil {
+		m.live.Close()
+		m.live = nil
+	}
+	m.liveSpaceID = spaceID
+	return joinLive(spaceID)
+}
+
+// updateLive handles the live connection's messages. Messages for a space
+// that's no longer followed are dropped.
+func (m *model) updateLive(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case liveJoinedMsg:
+		if msg.spaceID != m.liveSpaceID {
+			msg.broadcast.Close()
+			return nil
+		}
+		m.live = msg.broadcast
+		return listenLive(msg.spaceID, m.live)
+	case liveMsg:
+		if msg.spaceID != m.liveSpaceID || m.live == nil {
+			return nil
+		}
+		cmd := m.applyLive(msg.msg)
+		return tea.Batch(cmd, listenLive(msg.spaceID, m.live))
+	case liveClosedMsg:
+		if msg.spaceID != m.liveSpaceID {
+			return nil
+		}
+		m.live = nil
+		return tea.Tick(liveRetryDelay, func(time.Time) tea.Msg { return liveRetryMsg{msg.spaceID} })
+	case liveRetryMsg:
+		if msg.spaceID != m.liveSpaceID || m.live != nil {
+			return nil
+		}
+		return joinLive(msg.spaceID)
+	case liveFadeMsg:
+		for id, changed := range m.liveChanged {
+			if time.Since(changed) >= liveHighlight {
+				delete(m.liveChanged, id)
+			}
+		}
+		if m.currentView == "cards" {
+			m.refreshCardItems()
+		}
+	}
+	return nil
+}
+
+// applyLive applies a collaborator's change to the open space.
+func (m *model) applyLive(msg kinopio.BroadcastMessage) tea.Cmd {
+	if msg.SpaceID != "" && msg.SpaceID != m.selectedSpace.ID {
+		return nil
+	}
+	var item struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(msg.Updates, &item) != nil || item.ID == "" {
+		return nil
+	}
+
+	space := &m.selectedSpace
+	switch msg.Message {
+	case "createCard", "updateCard", "restoreRemovedCard":
+		// Merge into a copy, so a bad update leaves the space as it was.
+		var card Card
+		i := cardIndex(space.Cards, item.ID)
+		if i >= 0 {
+			card = space.Cards[i]
+		}
+		if mergeJSON(&card, msg.Updates) != nil {
+			return nil
+		}
+		if i < 0 {
+			space.Cards = append(space.Cards, card)
+		} else {
+			space.Cards[i] = card
+		}
+		if m.selectedCard.ID == item.ID {
+			m.selectedCard = card
+		}
+		m.liveChanged[item.ID] = time.Now()
+	case "removeCard":
+		m.removeLocalCard(item.ID)
+	case "createConnection", "updateConnection":
+		var conn Connection
+		i := -1
+		for j, c := range space.Connections {
+			if c.ID == item.ID {
+				conn, i = c, j
+			}
+		}
+		if mergeJSON(&conn, msg.Updates) != nil {
+			return nil
+		}
+		if i < 0 {
+			space.Connections = append(space.Connections, conn)
+		} else {
+			space.Connections[i] = conn
+		}
+	case "removeConnection":
+		for j, conn := range space.Connections {
+			if conn.ID == item.ID {
+				space.Connections = append(space.Connections[:j:j], space.Connections[j+1:]...)
+				break
+			}
+		}
+	default:
+		return nil
+	}
+
+	// Leave the card alone while its name is being edited, and the canvas
+	// where it is, since redrawing it moves the cursor.
+	if m.nameInput == nil && m.currentView != "canvas" {
+		m.refreshSpaceView()
+	}
+	return tea.Tick(liveHighlight, func(time.Time) tea.Msg { return liveFadeMsg{} })
+}
+
+func cardIndex(cards []Card, id string) int {
+	for i, card := range cards {
+		if card.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeJSON overwrites the fields of v that are present in updates.
+func mergeJSON(v interface{}, updates json.RawMessage) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	changed := map[string]json.RawMessage{}
+	if err := json.Unmarshal(updates, &changed); err != nil {
+		return err
+	}
+	for name, value := range changed {
+		fields[name] = value
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// recentlyChanged reports whether a collaborator changed a card in the
+// last few seconds.
+func (m *model) recentlyChanged(cardID string) bool {
+	changed, ok := m.liveChanged[cardID]
+	return ok && time.Since(changed) < liveHighlight
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
+)
+
+func TestApplyLiveIgnoresBadUpdates(t *testing.T) {
+	m := &model{liveChanged: map[string]time.Time{}}
+	m.selectedSpace = Space{
+		ID:          "s",
+		Cards:       []Card{{ID: "a", Name: "A", X: 10}},
+		Connections: []Connection{{ID: "ab", StartItemID: "a"}},
+	}
+	bad := func(message, updates string) kinopio.BroadcastMessage {
+		return kinopio.BroadcastMessage{Message: message, SpaceID: "s", Updates: json.RawMessage(updates)}
+	}
+
+	m.applyLive(bad("createCard", `{"id": "b", "x": "left"}`))
+	m.applyLive(bad("updateCard", `{"id": "a", "x": "left"}`))
+	m.applyLive(bad("createConnection", `{"id": "bc", "startItemId": 1}`))
+	m.applyLive(bad("updateConnection", `{"id": "ab", "startItemId": 1}`))
+
+	if cards := m.selectedSpace.Cards; len(cards) != 1 || cards[0].Name != "A" || cards[0].X != 10 {
+		t.Errorf("cards after bad updates: %+v", cards)
+	}
+	if conns := m.selectedSpace.Connections; len(conns) != 1 || conns[0].StartItemID != "a" {
+		t.Errorf("connections after bad updates: %+v", conns)
+	}
+}
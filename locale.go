@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateTimeSettings controls how dates, times and numbers are shown. They
+// come from the environment: the locale in LC_ALL, LC_TIME or LANG, the
+// time zone in KINOPIO_TZ (or TZ), and KINOPIO_CLOCK=12h|24h to override
+// the locale's usual clock.
+type dateTimeSettings struct {
+	location   *time.Location
+	dateLayout string
+	clock24    bool
+	thousands  string // Digit group separator
+}
+
+// displaySettings is read once at startup.
+var displaySettings = loadDateTimeSettings()
+
+// Regions that conventionally write dates month-first, use a 12-hour
+// clock, or write dates year-first.
+var (
+	monthFirstRegions = map[string]bool{"US": true, "PH": true, "FM": true}
+	clock12Regions    = map[string]bool{"US": true, "CA": true, "AU": true, "NZ": true, "IN": true, "PH": true, "PK": true, "EG": true}
+	yearFirstRegions  = map[string]bool{"CN": true, "JP": true, "KR": true, "TW": true, "HU": true, "LT": true, "SE": true, "CA": true}
+)
+
+// Digit group separators by language; anything else uses a comma.
+var thousandsSeparators = map[string]string{
+	"de": ".", "es": ".", "it": ".", "nl": ".", "pt": ".", "da": ".", "id": ".", "tr": ".", "el": ".",
+	"fr": " ", "ru": " ", "pl": " ", "sv": " ", "fi": " ", "nb": " ", "cs": " ", "sk": " ", "uk": " ",
+}
+
+// Languages that separate day, month and year with dots.
+var dottedDateLanguages = map[string]bool{"de": true, "ru": true, "pl": true, "fi": true, "nb": true, "cs": true, "sk": true, "uk": true, "tr": true}
+
+func loadDateTimeSettings() dateTimeSettings {
+	lang, region := parseLocale(firstEnv("LC_ALL", "LC_TIME", "LANG"))
+
+	s := dateTimeSettings{
+		location:   time.Local,
+		dateLayout: "2006-01-02",
+		clock24:    !clock12Regions[region],
+		thousands:  ",",
+	}
+	if sep, ok := thousandsSeparators[lang]; ok {
+		s.thousands = sep
+	}
+	switch {
+	case region == "" || yearFirstRegions[region]:
+		// ISO dates when there's no locale to go by.
+	case monthFirstRegions[region]:
+		s.dateLayout = "01/02/2006"
+	case dottedDateLanguages[lang]:
+		s.dateLayout = "02.01.2006"
+	default:
+		s.dateLayout = "02/01/2006"
+	}
+
+	if name := os.Getenv("KINOPIO_TZ"); name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			s.location = loc
+		}
+	}
+	switch strings.ToLower(os.Getenv("KINOPIO_CLOCK")) {
+	case "12h", "12":
+		s.clock24 = false
+	case "24h", "24":
+		s.clock24 = true
+	}
+	return s
+}
+
+// parseLocale splits a POSIX locale such as "en_GB.UTF-8" into its
+// language and region. "C" and "POSIX" have neither.
+func parseLocale(locale string) (lang, region string) {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	if locale == "C" || locale == "POSIX" {
+		return "", ""
+	}
+	lang, region, _ = strings.Cut(locale, "_")
+	return strings.ToLower(lang), strings.ToUpper(region)
+}
+
+// firstEnv returns the first of the environment variables that is set.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// formatDateTime shows t as a date and time in the user's locale and time
+// zone, e.g. "05/03/2024 14:02" or "03/05/2024 2:02 PM".
+func (s dateTimeSettings) formatDateTime(t time.Time) string {
+	layout := s.dateLayout + " 15:04"
+	if !s.clock24 {
+		layout = s.dateLayout + " 3:04 PM"
+	}
+	return t.In(s.location).Format(layout)
+}
+
+// formatNumber groups an integer's digits, e.g. "12,345" or "12.345".
+func (s dateTimeSettings) formatNumber(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(s.thousands)
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
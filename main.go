@@ -1,17 +1,27 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
 )
 
 type model struct {
@@ -24,114 +34,826 @@ type model struct {
 	spaces        []Space
 	selectedSpace Space
 	selectedCard  Card
-}
+	confirm       *confirmDialog
+	numberCards   bool
+	jumpInput     string
+	cardSort      cardSort
+	boxFilter     *Box
+	tagFilter     string // Only list cards with this tag
+	prompt        *prompt
+	hideComments  bool
+	openTasksOnly bool // Only list unfinished task cards
+	showUpdated   bool // Show last-updated times in card descriptions
+	showAdvanced  bool // Expand the advanced fields in cardDetails
+	spaceSort     spaceSort
+	groupSpaces   spaceGrouping
+	favorites     map[string]bool // IDs of the user's favorite spaces
+	favoritesOnly bool            // List only the favorite spaces
+	resume        bool            // Reopen the last space and card, until they're opened
+	exploreSpaces []Space         // Public spaces, once fetched for the explore view
+	readOnlySpace string          // The public space opened from Explore
+	notifications []Notification  // Fetched in the background for the unread count
+	account       Account         // The signed-in user, as the account view last fetched it
+	groupByBox    bool            // Section the cards list by box
+	switcher      *spaceSwitcher
+	width         int
+	height        int
+	search        *cardSearch
+	find          *cardFind
+	palette       *commandPalette
+	colorPicker   *colorPicker
+	rawText       bool   // Show card text as it is, rather than as markdown
+	copying       bool   // y was pressed on a card, and the next key says what to copy
+	showHelp      bool   // Show the help overlay
+	accountName   string // The signed-in user, for the status bar
+	accountID     string // The signed-in user's ID, for the cards they add
+	toastText     string
+	toastSeq      int
+	bulkAdd       *bulkAddForm
+	presentation  *presentation
+	kanban        *kanbanBoard
+	canvas        *canvasView
+	nudgeStep     int                   // Pixels a shift+arrow moves a card
+	nudgeSeq      int                   // Counts nudges, so only the last one is saved
+	nameInput     *textinput.Model      // Editing the card name in cardDetails
+	removedCards  []Card                // The selected space's removed cards
+	journal       bool                  // Open today's journal on start
+	startSpace    string                // The configured default space, until it's opened
+	keymap        map[string]tea.KeyMsg // Configured keybindings
+	userTags      []Tag                 // Tags across all spaces
+	selected      map[string]bool       // Selected card IDs
+	undoStack     []undoEdit            // Card edits made this session, oldest first
+	redoStack     []undoEdit            // Undone edits, most recently undone last
+	state         localState
+	spaceCache    map[string]Space     // Fetched spaces, keyed by ID
+	offline       bool                 // Showing cached data that couldn't be refreshed
+	refreshEvery  time.Duration        // How often to poll for changes, if at all
+	loadProgress  *spaceLoadingMsg     // How far the space being opened has loaded
+	opening       Space                // The space being opened
+	retryOpen     *Space               // The space to open again when its error is retried
+	reauth        *setupModel          // Asks for a new API key when the API rejects it
+	live          *kinopio.Broadcast   // Live changes to the followed space
+	liveSpaceID   string               // The space being followed
+	liveChanged   map[string]time.Time // When collaborators last changed each card
+	pendingWrites int                  // Card writes queued while offline
+	syncScheduled bool                 // A retry of the queued writes is coming up
+	retryAt       time.Time            // When a failed request is retried, if one is
+	retryTicking  bool                 // The retry countdown is running
+	rateLimited   bool                 // The retry is because of the API's rate limit
 
-type Card struct {
-	ID              string `json:"id"`
-	Name            string `json:"name"`
-	X               int    `json:"x"`
-	Y               int    `json:"y"`
-	BackgroundColor string `json:"backgroundColor"` // Add backgroundColor field
-}
+	// The views drilled down through to get here, for b to go back to
+	views       []viewFrame
+	refiltering *viewFrame // A filter being put back by popView
+	cursors     map[cursorKey]cursorPosition
 
-type Box struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
+	// Back/forward navigation history
+	here            location
+	back            []location
+	forward         []location
+	pendingLocation *location // Restored once its space has loaded
 
-type Space struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Url   string `json:"url"`
-	Cards []Card `json:"cards"`
-	Boxes []Box  `json:"boxes"`
+	// listCmds collects commands returned when the list's items change,
+	// such as re-running an active filter.
+	listCmds []tea.Cmd
 }
 
+// The API types are used throughout the app under their own names.
+type (
+	Card           = kinopio.Card
+	Box            = kinopio.Box
+	Connection     = kinopio.Connection
+	ConnectionType = kinopio.ConnectionType
+	User           = kinopio.User
+	Space          = kinopio.Space
+	Tag            = kinopio.Tag
+	Notification   = kinopio.Notification
+	Account        = kinopio.Account
+)
+
 func (m *model) Init() tea.Cmd {
 	m.loading = true
 	m.currentView = "list"
-	return tea.Batch(fetchSpaces(), m.spinner.Tick)
+	// Send anything queued while offline last time.
+	var sync tea.Cmd
+	if n := writes.len(); n > 0 {
+		m.pendingWrites = n
+		m.syncScheduled = true
+		sync = syncWrites()
+	}
+	if m.journal {
+		return tea.Batch(tea.Sequence(fetchSpaces(), m.openJournal()), m.spinner.Tick, warmTick(), sync, m.refreshTick(), fetchUser(), fetchFavorites(), fetchNotifications(false))
+	}
+	if spaces, ok := loadCachedSpaces(); ok {
+		m.loading = false
+		m.spaces = spaces
+		m.showSpaces()
+		return tea.Batch(refreshSpaces(), warmTick(), sync, m.openStartSpace(), m.refreshTick(), fetchFavorites(), fetchNotifications(false))
+	}
+	return tea.Batch(fetchSpaces(), m.spinner.Tick, warmTick(), sync, m.refreshTick(), fetchUser(), fetchFavorites(), fetchNotifications(false))
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := m.frame()
+	cmd := m.update(m.remapKey(msg))
+	if m.find != nil && m.currentView != "cards" {
+		m.find = nil
+	}
+	m.recordView(before)
+	m.recordLocation()
+	cmds := append(m.listCmds, cmd)
+	m.listCmds = nil
+	return m, tea.Batch(cmds...)
+}
+
+// setItems replaces the list's items. Use it instead of m.list.SetItems
+// so the list's follow-up command isn't lost.
+func (m *model) setItems(items []list.Item) {
+	m.listCmds = append(m.listCmds, m.list.SetItems(items))
+}
+
+// setItem replaces the item at index in the unfiltered list.
+func (m *model) setItem(index int, item list.Item) {
+	m.listCmds = append(m.listCmds, m.list.SetItem(index, item))
+}
+
+func (m *model) update(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case spacesMsg:
+		changed := m.offline || !reflect.DeepEqual(m.spaces, msg.spaces)
 		m.spaces = msg.spaces
-		items := make([]list.Item, len(msg.spaces))
-		for i, space := range msg.spaces {
-			items[i] = listItem{space}
+		m.offline = false
+		if m.currentView == "list" && changed {
+			m.keepCursor(m.showSpaces)
 		}
-		m.list.SetItems(items)
 		m.loading = false
-	case spaceDetailsMsg:
+		cmds = append(cmds, m.warmSpaces(), m.prefetchSpaces(), writeCache("spaces", msg.spaces), m.openStartSpace())
+	case liveJoinedMsg, liveMsg, liveClosedMsg, liveRetryMsg, liveFadeMsg:
+		cmds = append(cmds, m.updateLive(msg))
+	case refreshTickMsg:
+		cmds = append(cmds, m.refresh(), m.refreshTick())
+	case retryingMsg:
+		cmds = append(cmds, m.retrying(msg.retry))
+	case retryTickMsg:
+		cmds = append(cmds, m.retryTicked())
+	case notificationsMsg:
+		m.notifications = msg.notifications
+		if msg.show {
+			m.loading = false
+			cmds = append(cmds, m.showNotifications())
+		}
+	case accountMsg:
+		m.loading = false
+		m.account = msg.account
+		m.showAccount()
+	case exploreMsg:
+		m.loading = false
+		m.exploreSpaces = msg.spaces
+		m.showExplore()
+	case favoritesMsg:
+		m.favorites = msg.ids
+		if m.currentView == "list" {
+			m.keepCursor(m.showSpaces)
+		}
+	case userMsg:
+		m.accountID, m.accountName = msg.id, msg.name
+	case editorDoneMsg:
+		cmds = append(cmds, m.editorDone(msg))
+	case toastExpiredMsg:
+		m.toastExpired(msg)
+	case spaceLoadingMsg:
+		if m.loading && msg.spaceID == m.opening.ID {
+			m.loadProgress = &msg
+		}
+		cmds = append(cmds, msg.next)
+	case spaceRefreshedMsg:
+		m.offline = false
+		cmds = append(cmds, m.spaceRefreshed(msg.Space))
+	case writeQueueMsg:
+		cmds = append(cmds, m.writesQueued(msg.pending))
+		if m.currentView == "list" {
+			m.showSpaces()
+		}
+	case queueSyncedMsg:
+		cmds = append(cmds, m.writesSynced(msg))
+		if m.currentView == "list" {
+			m.showSpaces()
+		}
+	case syncTickMsg:
+		cmds = append(cmds, syncWrites())
+	case refreshFailedMsg:
+		m.offline = true
+		if m.currentView == "list" {
+			m.showSpaces()
+		}
+	case spaceCreatedMsg:
+		m.spaces = append([]Space{msg.Space}, m.spaces...)
 		m.selectedSpace = msg.Space
+		m.selected = map[string]bool{}
+		m.loading = false
+		m.showDetails()
+		cmds = append(cmds, m.toast("Space created"))
+	case linkCheckDoneMsg:
+		m.loading = false
+		m.showDeadLinks(msg.results)
+	case cardsArchivedMsg:
 		m.loading = false
-		m.currentView = "details"
-		m.list.Title = msg.Space.Name
-		detailItems := []list.Item{
-			detailListItem{"Cards", fmt.Sprintf("%d cards", len(msg.Space.Cards))},
-			detailListItem{"Boxes", fmt.Sprintf("%d boxes", len(msg.Space.Boxes))},
+		m.replaceCards(msg.cards...)
+		if len(msg.failed) > 0 {
+			m.err = fmt.Errorf("failed to archive %s:\n%s", plural(len(msg.failed), "link"), strings.Join(msg.failed, "\n"))
+		} else if len(msg.cards) > 0 {
+			cmds = append(cmds, m.toast("Archived links in "+plural(len(msg.cards), "card")))
 		}
-		m.list.SetItems(detailItems)
-	case error:
-		m.err = msg
+	case userTagsMsg:
+		m.loading = false
+		m.userTags = msg.tags
+		m.showUserTags(msg.tags)
+	case taggedCardsMsg:
 		m.loading = false
+		m.showTaggedCardsEverywhere(msg.tag, msg.cards)
+	case nudgeSaveMsg:
+		cmds = append(cmds, m.saveNudge(msg))
+	case cardsTransferredMsg:
+		cmds = append(cmds, m.cardsTransferred(msg))
+	case journalMsg:
+		m.journalOpened(msg.Space)
+	case cardCreatedMsg:
+		m.replaceCards(msg.Card)
+		cmds = append(cmds, m.toast("Card created"))
+	case cardCreateFailedMsg:
+		m.removeLocalCard(msg.cardID)
+		if m.currentView == "cards" {
+			m.setCardItems()
+		}
+		cmds = append(cmds, m.showError(msg.err))
+	case removedCardsMsg:
+		m.loading = false
+		if msg.spaceID == m.selectedSpace.ID {
+			m.removedCards = msg.cards
+			m.showRemovedCards()
+		}
+	case cardRestoredMsg:
+		m.cardRestored(msg.Card)
+		cmds = append(cmds, m.toast("Card restored"))
+	case spaceWarmedMsg:
+		if m.isWarm(msg.Space.ID) {
+			m.spaceCache[msg.Space.ID] = msg.Space
+		}
+	case warmTickMsg:
+		cmds = append(cmds, m.warmSpaces(), warmTick())
+	case spacePrefetchedMsg:
+		// A space opened meanwhile is newer than its prefetched copy.
+		if _, ok := m.spaceCache[msg.Space.ID]; !ok && msg.Space.ID != m.selectedSpace.ID {
+			m.spaceCache[msg.Space.ID] = msg.Space
+			cmds = append(cmds, writeCache(spaceCacheName(msg.Space.ID), msg.Space))
+		}
+		if m.search != nil {
+			m.reindexSearch()
+		}
+		cmds = append(cmds, msg.next)
+	case spaceDetailsMsg:
+		// A space the user has since stopped waiting for is still worth
+		// keeping, so opening it later is instant.
+		if !m.loading || !m.isOpening(msg.Space) {
+			if msg.Space.ID != m.selectedSpace.ID {
+				m.spaceCache[msg.Space.ID] = msg.Space
+			}
+			cmds = append(cmds, writeCache(spaceCacheName(msg.Space.ID), msg.Space))
+			break
+		}
+		m.loading = false
+		m.loadProgress = nil
+		cmds = append(cmds, writeCache(spaceCacheName(msg.Space.ID), msg.Space), m.spaceOpened(msg.Space))
+	case error:
+		cmds = append(cmds, m.showError(msg))
+	case setupDoneMsg:
+		cmds = append(cmds, m.reauthenticated(msg))
 	case tea.WindowSizeMsg:
-		m.list.SetSize(msg.Width, msg.Height-4)
+		m.width, m.height = msg.Width, msg.Height
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
-		case "enter":
+		if m.reauth != nil && msg.String() != "ctrl+c" {
+			return m.updateReauth(msg)
+		}
+		if m.err != nil {
+			return m.updateError(msg)
+		}
+		if m.confirm != nil && msg.String() != "ctrl+c" {
+			done, cmd := m.confirm.Update(msg)
+			if done {
+				m.confirm = nil
+			}
+			return cmd
+		}
+		if m.prompt != nil && msg.String() != "ctrl+c" {
+			return m.updatePrompt(msg)
+		}
+		if m.bulkAdd != nil && msg.String() != "ctrl+c" {
+			return m.updateBulkAdd(msg)
+		}
+		if m.switcher != nil && msg.String() != "ctrl+c" {
+			return m.updateSwitcher(msg)
+		}
+		if m.search != nil && msg.String() != "ctrl+c" {
+			return m.updateSearch(msg)
+		}
+		if m.palette != nil && msg.String() != "ctrl+c" {
+			return m.updatePalette(msg)
+		}
+		if m.colorPicker != nil && msg.String() != "ctrl+c" {
+			return m.updateColorPicker(msg)
+		}
+		if m.copying && msg.String() != "ctrl+c" {
+			return m.updateCopy(msg)
+		}
+		if m.find != nil && m.find.editing && msg.String() != "ctrl+c" {
+			return m.updateFind(msg)
+		}
+		if m.nameInput != nil && msg.String() != "ctrl+c" {
+			return m.updateNameInput(msg)
+		}
+		if m.presentation != nil && msg.String() != "ctrl+c" {
+			if m.presentation.Update(msg) {
+				m.presentation = nil
+			}
+			return nil
+		}
+		if m.showHelp {
+			return m.updateHelp(msg)
+		}
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		if blocked, cmd := m.blockEdit(msg); blocked {
+			return cmd
+		}
+		if m.currentView == "kanban" {
+			if handled, cmd := m.updateKanban(msg); handled {
+				return cmd
+			}
+		}
+		if m.currentView == "canvas" {
+			if handled, cmd := m.updateCanvas(msg); handled {
+				return cmd
+			}
+		}
+		switch {
+		case key.Matches(msg, keys.Quit):
+			return tea.Quit
+		case m.loading && m.opening.ID != "" && key.Matches(msg, keys.Back, keys.Cancel):
+			m.cancelOpen()
+			return nil
+		case key.Matches(msg, keys.HistoryBack):
+			return m.goBack()
+		case m.currentView == "list" && key.Matches(msg, keys.Explore):
+			return m.openExplore()
+		case m.currentView == "list" && key.Matches(msg, keys.Account):
+			return m.openAccount()
+		case key.Matches(msg, keys.ExportGraph):
+			if m.currentView == "details" || m.currentView == "connections" || m.currentView == "graph" {
+				return m.exportGraph()
+			}
+		case key.Matches(msg, keys.HistoryForward):
+			return m.goForward()
+		case key.Matches(msg, keys.Group):
+			if m.currentView == "list" {
+				m.groupSpaces = m.groupSpaces.next()
+				m.showSpaces()
+				return nil
+			}
+			if m.currentView == "cards" {
+				m.groupByBox = !m.groupByBox
+				m.setCardItems()
+				return nil
+			}
+		case m.find != nil && key.Matches(msg, keys.NextMatch):
+			m.nextMatch(1)
+			return nil
+		case m.find != nil && key.Matches(msg, keys.PrevMatch):
+			m.nextMatch(-1)
+			return nil
+		case key.Matches(msg, keys.New):
+			if m.currentView == "list" {
+				return m.startNewSpace()
+			}
+			if m.currentView == "cards" {
+				return m.newCard()
+			}
+			if m.currentView == "boxes" {
+				return m.newBox()
+			}
+			if m.currentView == "cardDetails" {
+				return m.addComment()
+			}
+		case key.Matches(msg, keys.NewRandom):
+			if m.currentView == "list" {
+				return m.startCreateSpace(randomName())
+			}
+		case key.Matches(msg, keys.Warm):
+			if m.currentView == "list" {
+				return m.toggleWarm()
+			}
+		case key.Matches(msg, keys.Inbox):
+			return m.captureToInbox()
+		case key.Matches(msg, keys.Journal):
+			return m.openJournal()
+		case key.Matches(msg, keys.Search):
+			if m.currentView == "list" && len(m.spaces) > 0 {
+				return m.openSearch()
+			}
+		case key.Matches(msg, keys.Find):
+			if m.currentView == "cards" {
+				return m.openFind()
+			}
+		case key.Matches(msg, keys.Palette):
+			return m.openPalette()
+		case key.Matches(msg, keys.Help):
+			m.showHelp = true
+			return nil
+		case key.Matches(msg, keys.Notifications):
+			return m.openNotifications()
+		case key.Matches(msg, keys.SwitchSpace):
+			if len(m.spaces) > 0 {
+				m.switcher = newSpaceSwitcher(m.switcherSpaces())
+				return textinput.Blink
+			}
+		case key.Matches(msg, keys.JumpTo):
+			if m.currentView == "cards" {
+				m.jumpInput += msg.String()
+				return nil
+			}
+		case key.Matches(msg, keys.Backspace):
+			if m.currentView == "cards" && m.jumpInput != "" {
+				m.jumpInput = m.jumpInput[:len(m.jumpInput)-1]
+				return nil
+			}
+		case key.Matches(msg, keys.Cancel):
+			if m.jumpInput != "" {
+				m.jumpInput = ""
+				return nil
+			}
+			if m.find != nil {
+				m.closeFind()
+				return nil
+			}
+			// Without a filter to clear, esc goes back like b does.
+			if m.list.FilterState() == list.Unfiltered {
+				return m.popView()
+			}
+		case key.Matches(msg, keys.NumberCards):
+			if m.currentView == "cards" {
+				m.numberCards = !m.numberCards
+				m.setCardItems()
+				return nil
+			}
+		case key.Matches(msg, keys.Sort):
+			if m.currentView == "list" {
+				m.spaceSort = m.spaceSort.next()
+				m.showSpaces()
+				return nil
+			}
+			if m.currentView == "cards" {
+				m.cardSort = m.cardSort.next()
+				m.setCardItems()
+				return nil
+			}
+		case key.Matches(msg, keys.Comment):
+			if m.currentView == "cards" || m.currentView == "cardDetails" {
+				return m.toggleComment()
+			}
+		case key.Matches(msg, keys.BulkAdd):
+			if m.currentView == "cards" {
+				return m.openBulkAdd()
+			}
+		case key.Matches(msg, keys.Edit):
+			if m.currentView == "cardDetails" {
+				return m.editCardName()
+			}
+			if m.currentView == "connections" {
+				return m.editConnectionLabel()
+			}
+			if m.currentView == "boxes" {
+				return m.renameBox()
+			}
+		case key.Matches(msg, keys.ExternalEdit):
+			if m.currentView == "cards" || m.currentView == "cardDetails" {
+				return m.editInEditor()
+			}
+		case key.Matches(msg, keys.EditSpace):
+			if m.currentView == "cards" {
+				return m.editSpaceText()
+			}
+		case key.Matches(msg, keys.Resize):
+			if m.currentView == "boxes" {
+				return m.resizeBox()
+			}
+		case key.Matches(msg, keys.Advanced):
+			if m.currentView == "cardDetails" {
+				m.showAdvanced = !m.showAdvanced
+				return m.showCardDetails()
+			}
+		case key.Matches(msg, keys.ShowUpdated):
+			if m.currentView == "cards" {
+				m.showUpdated = !m.showUpdated
+				m.setCardItems()
+				return nil
+			}
+		case key.Matches(msg, keys.Pin):
+			if m.currentView == "cards" {
+				return m.togglePin()
+			}
+		case key.Matches(msg, keys.Undo):
+			if m.inSpace() {
+				return m.undo()
+			}
+		case key.Matches(msg, keys.Redo):
+			if m.inSpace() {
+				return m.redo()
+			}
+		case key.Matches(msg, keys.SelectMatching):
+			if m.currentView == "cards" {
+				m.selectMatching()
+				return nil
+			}
+		case key.Matches(msg, keys.Select):
+			if m.currentView == "cards" {
+				m.toggleSelected()
+				return nil
+			}
+		case key.Matches(msg, keys.BulkActions):
+			if m.currentView == "cards" {
+				m.showBulkActions()
+				return nil
+			}
+		case key.Matches(msg, keys.Archive):
+			if m.currentView == "cards" || m.currentView == "cardDetails" {
+				m.confirmArchive()
+				return nil
+			}
+		case key.Matches(msg, keys.Task):
+			if m.currentView == "cards" || m.currentView == "cardDetails" {
+				return m.toggleTask()
+			}
+		case m.currentView == "list" && key.Matches(msg, keys.Favorite):
+			return m.toggleFavorite()
+		case key.Matches(msg, keys.Color):
+			if m.currentView == "cards" || m.currentView == "cardDetails" {
+				if card, ok := m.selectedListCard(); ok {
+					m.pickColor([]Card{card})
+				}
+				return nil
+			}
+		case key.Matches(msg, keys.Yank):
+			return m.startCopy()
+		case key.Matches(msg, keys.Open):
+			if m.currentView == "cardDetails" {
+				return m.openCardLink()
+			}
+			if m.currentView == "list" || m.inSpace() {
+				return m.openSpaceInKinopio()
+			}
+		case key.Matches(msg, keys.OpenInKinopio):
+			if m.currentView == "cards" || m.currentView == "cardDetails" {
+				return m.openCardInKinopio()
+			}
+		case key.Matches(msg, keys.RawText):
+			if m.currentView == "cardDetails" || (m.currentView == "cards" && m.splitActive()) {
+				m.rawText = !m.rawText
+				return nil
+			}
+		case key.Matches(msg, keys.Split):
+			if m.currentView == "list" || m.currentView == "cards" {
+				if err := m.toggleSplit(); err != nil {
+					return func() tea.Msg { return err }
+				}
+				return nil
+			}
+		case key.Matches(msg, keys.GrowList, keys.ShrinkList):
+			if m.splitActive() && !m.previewBelow() {
+				step := splitStep
+				if key.Matches(msg, keys.ShrinkList) {
+					step = -splitStep
+				}
+				if err := m.resizeSplit(step); err != nil {
+					return func() tea.Msg { return err }
+				}
+				return nil
+			}
+		case key.Matches(msg, keys.Kanban):
+			if m.currentView == "cards" {
+				m.showKanban()
+				return nil
+			}
+		case key.Matches(msg, keys.Canvas):
+			if m.currentView == "cards" {
+				m.showCanvas()
+				return nil
+			}
+		case key.Matches(msg, keys.OpenTasks):
+			if m.currentView == "cards" {
+				m.openTasksOnly = !m.openTasksOnly
+				m.setCardItems()
+				return nil
+			}
+		case key.Matches(msg, keys.Nudge):
+			if m.currentView == "cardDetails" {
+				dx, dy := 0, 0
+				switch msg.String() {
+				case "shift+up":
+					dy = -1
+				case "shift+down":
+					dy = 1
+				case "shift+left":
+					dx = -1
+				case "shift+right":
+					dx = 1
+				}
+				return m.nudgeCard(dx, dy)
+			}
+		case key.Matches(msg, keys.NudgeStep):
+			if m.currentView == "cardDetails" {
+				if msg.String() == "]" {
+					m.cycleNudgeStep(1)
+				} else {
+					m.cycleNudgeStep(-1)
+				}
+				return nil
+			}
+		case key.Matches(msg, keys.Move, keys.Copy):
+			if m.currentView == "cards" || m.currentView == "cardDetails" {
+				m.pickTransferTarget(key.Matches(msg, keys.Move))
+				return nil
+			}
+		case key.Matches(msg, keys.Tags):
+			if m.currentView == "list" {
+				return m.openUserTags()
+			}
+		case key.Matches(msg, keys.ImportMarks):
+			if m.currentView == "cards" {
+				return m.importBookmarksPrompt()
+			}
+		case key.Matches(msg, keys.CheckLinks):
+			if m.currentView == "cards" {
+				return m.startLinkCheck()
+			}
+		case key.Matches(msg, keys.Duplicates):
+			if m.currentView == "cards" {
+				m.showDuplicates()
+				return nil
+			}
+		case key.Matches(msg, keys.Delete):
+			if m.currentView == "duplicates" {
+				m.deleteDuplicate()
+				return nil
+			}
+			if m.currentView == "cards" {
+				m.removeSelectedCard()
+				return nil
+			}
+			if m.currentView == "boxes" {
+				m.deleteBox()
+				return nil
+			}
+		case key.Matches(msg, keys.Refresh):
+			if m.currentView == "removed" {
+				return m.restoreCard()
+			}
+			if cmd := m.refresh(); cmd != nil {
+				return tea.Batch(cmd, m.toast("Refreshing…"))
+			}
+		case key.Matches(msg, keys.Merge):
+			if m.currentView == "duplicates" {
+				m.mergeDuplicate()
+				return nil
+			}
+		case key.Matches(msg, keys.Present):
+			if m.currentView == "cards" {
+				m.startPresentation()
+				return nil
+			}
+		case key.Matches(msg, keys.Arrange):
+			if m.currentView == "cards" {
+				m.confirmArrangeGrid()
+				return nil
+			}
+		case key.Matches(msg, keys.HideComments):
+			if m.currentView == "cards" {
+				m.hideComments = !m.hideComments
+				m.setCardItems()
+				return nil
+			}
+		case key.Matches(msg, keys.Enter):
+			if m.currentView == "cards" && m.jumpInput != "" {
+				m.jumpToCard()
+				return nil
+			}
 			if m.currentView == "list" {
 				if item, ok := m.list.SelectedItem().(listItem); ok {
-					m.loading = true
-					return m, fetchSpaceDetails(item.Space.ID)
+					return m.openSpace(item.Space)
+				}
+				if _, ok := m.list.SelectedItem().(inboxListItem); ok {
+					return m.openInbox()
 				}
 			} else if m.currentView == "details" {
-				if item, ok := m.list.SelectedItem().(detailListItem); ok && item.title == "Cards" {
-					m.currentView = "cards"
-					m.list.Title = m.selectedSpace.Name + " → Cards"
-					cardItems := make([]list.Item, len(m.selectedSpace.Cards))
-					for i, card := range m.selectedSpace.Cards {
-						cardItems[i] = cardListItem{card}
+				if item, ok := m.list.SelectedItem().(detailListItem); ok {
+					switch item.title {
+					case "Cards":
+						m.boxFilter = nil
+						m.tagFilter = ""
+						m.showCards()
+					case "Tags":
+						m.showTags()
+					case "Boxes":
+						m.showBoxes()
+					case "Connections":
+						m.showConnections()
+					case "Graph":
+						m.showGraph()
+					case "Removed cards":
+						return m.openRemovedCards()
+					case "Actions":
+						m.showSpaceActions()
+					case "Duplicate":
+						return m.duplicateSpace(m.selectedSpace)
+					case "Privacy":
+						return m.cyclePrivacy()
+					case "Invite":
+						return m.copyInviteURL()
+					}
+				}
+			} else if m.currentView == "spaceActions" {
+				if item, ok := m.list.SelectedItem().(detailListItem); ok {
+					switch item.title {
+					case "Rename":
+						return m.renameSpace()
+					case "Delete":
+						m.deleteSpace()
+						return nil
+					case "Export":
+						return m.exportSpace()
+					case "Duplicate":
+						return m.duplicateSpace(m.selectedSpace)
 					}
-					m.list.SetItems(cardItems)
+				}
+			} else if m.currentView == "templates" {
+				switch item := m.list.SelectedItem().(type) {
+				case detailListItem:
+					return m.newSpace()
+				case listItem:
+					return m.duplicateSpace(item.Space)
+				}
+			} else if m.currentView == "notifications" {
+				if item, ok := m.list.SelectedItem().(notificationItem); ok {
+					return m.openNotification(item.Notification)
+				}
+			} else if m.currentView == "explore" {
+				if item, ok := m.list.SelectedItem().(exploreListItem); ok {
+					return m.openExploreSpace(item.Space)
+				}
+			} else if m.currentView == "bulkActions" {
+				if item, ok := m.list.SelectedItem().(detailListItem); ok {
+					return m.runBulkAction(item.title)
+				}
+			} else if m.currentView == "boxes" {
+				if item, ok := m.list.SelectedItem().(boxListItem); ok {
+					box := item.Box
+					m.boxFilter = &box
+					m.tagFilter = ""
+					m.showCards()
 				}
 			} else if m.currentView == "cards" {
 				if item, ok := m.list.SelectedItem().(cardListItem); ok {
 					m.selectedCard = item.Card
 					m.currentView = "cardDetails"
-					return m, m.showCardDetails()
+					return m.showCardDetails()
 				}
-			}
-		case "b":
-			if m.currentView == "details" {
-				m.currentView = "list"
-				m.list.Title = "Spaces"
-				items := make([]list.Item, len(m.spaces))
-				for i, space := range m.spaces {
-					items[i] = listItem{space}
+			} else if m.currentView == "links" {
+				if item, ok := m.list.SelectedItem().(linkListItem); ok {
+					m.selectedCard = item.check.card
+					m.currentView = "cardDetails"
+					return m.showCardDetails()
 				}
-				m.list.SetItems(items)
-			} else if m.currentView == "cards" {
-				m.currentView = "details"
-				m.list.Title = m.selectedSpace.Name
-				detailItems := []list.Item{
-					detailListItem{"Cards", fmt.Sprintf("%d cards", len(m.selectedSpace.Cards))},
-					detailListItem{"Boxes", fmt.Sprintf("%d boxes", len(m.selectedSpace.Boxes))},
+			} else if m.currentView == "tags" {
+				if item, ok := m.list.SelectedItem().(tagListItem); ok {
+					m.showTaggedCards(item.name)
+				}
+			} else if m.currentView == "allTags" {
+				if item, ok := m.list.SelectedItem().(tagListItem); ok {
+					return m.openTaggedCards(item.name)
 				}
-				m.list.SetItems(detailItems)
-			} else if m.currentView == "cardDetails" {
-				m.currentView = "cards"
-				cardItems := make([]list.Item, len(m.selectedSpace.Cards))
-				for i, card := range m.selectedSpace.Cards {
-					cardItems[i] = cardListItem{card}
+			} else if m.currentView == "tagCards" {
+				if item, ok := m.list.SelectedItem().(taggedCardItem); ok {
+					return m.openTaggedCard(item.Card)
+				}
+			} else if m.currentView == "graph" {
+				if item, ok := m.list.SelectedItem().(graphCardItem); ok {
+					m.selectedCard = item.Card
+					m.currentView = "cardDetails"
+					return m.showCardDetails()
 				}
-				m.list.SetItems(cardItems)
 			}
+		case key.Matches(msg, keys.Back):
+			return m.popView()
 		}
 	}
 
@@ -141,88 +863,586 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	if m.prompt != nil {
+		var cmd tea.Cmd
+		m.prompt.input, cmd = m.prompt.input.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.bulkAdd != nil {
+		var cmd tea.Cmd
+		m.bulkAdd.text, cmd = m.bulkAdd.text.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.switcher != nil {
+		var cmd tea.Cmd
+		m.switcher.input, cmd = m.switcher.input.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.search != nil {
+		var cmd tea.Cmd
+		m.search.input, cmd = m.search.input.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.palette != nil {
+		var cmd tea.Cmd
+		m.palette.input, cmd = m.palette.input.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.find != nil && m.find.editing {
+		var cmd tea.Cmd
+		m.find.input, cmd = m.find.input.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.reauth != nil {
+		_, cmd := m.reauth.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.nameInput != nil {
+		var cmd tea.Cmd
+		*m.nameInput, cmd = m.nameInput.Update(msg)
+		m.refreshCardRows()
+		cmds = append(cmds, cmd)
+	}
+
 	if m.currentView == "cardDetails" {
 		var cmd tea.Cmd
 		m.cardTable, cmd = m.cardTable.Update(msg)
 		cmds = append(cmds, cmd)
 	}
 
+	m.resize()
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
 	cmds = append(cmds, cmd)
+	if _, ok := msg.(list.FilterMatchesMsg); ok && m.refiltering != nil {
+		m.finishRefilter()
+	}
 
-	return m, tea.Batch(cmds...)
+	return tea.Batch(cmds...)
 }
 
-func (m *model) showCardDetails() tea.Cmd {
-	columns := []table.Column{
-		{Title: "Field", Width: 15},
-		{Title: "Value", Width: 65},
+// openSpace fetches a space's details and shows them once they arrive.
+// Spaces already fetched, whether warm, prefetched or visited before, open
+// straight from the cache and refresh in the background, as do spaces
+// saved in the offline cache.
+func (m *model) openSpace(space Space) tea.Cmd {
+	m.opening = space
+	if cached, ok := m.spaceCache[space.ID]; ok {
+		return tea.Batch(m.spaceOpened(cached), refreshSpace(space.ID))
+	}
+	if cached, ok := loadCachedSpace(space.ID); ok {
+		return tea.Batch(m.spaceOpened(cached), refreshSpace(space.ID))
 	}
+	m.loading = true
+	return tea.Batch(fetchSpaceDetails(space.ID), m.spinner.Tick)
+}
 
-	// Determine the background color to use
-	bgColor := m.selectedCard.BackgroundColor
-	if bgColor == "" {
-		bgColor = "#e3e3e3" // Default color if none is specified
+// isOpening reports whether space is the one the user is waiting for. The
+// inbox is fetched by name, so its ID isn't known until it arrives.
+func (m *model) isOpening(space Space) bool {
+	if m.opening.ID == "" {
+		return isInbox(m.opening) && isInbox(space)
 	}
+	return space.ID == m.opening.ID
+}
 
-	// Use lipgloss to apply the background color to the cell
-	bgColorStyle := lipgloss.NewStyle().Background(lipgloss.Color(bgColor)).Render(bgColor)
+// spaceOpened shows a space that has just been opened, at the location
+// being returned to if there is one.
+func (m *model) spaceOpened(space Space) tea.Cmd {
+	if space.ID != m.selectedSpace.ID {
+		m.selected = map[string]bool{}
+		// Keep the space being left, with any changes made to it, so
+		// going back to it is instant too.
+		if m.selectedSpace.ID != "" {
+			m.spaceCache[m.selectedSpace.ID] = m.selectedSpace
+		}
+	}
+	m.opening = Space{}
+	m.selectedSpace = space
+	m.spaceCache[space.ID] = space
+	m.visitSpace(space.ID)
+	if m.pendingLocation != nil {
+		m.restoreLocation(*m.pendingLocation)
+		m.pendingLocation = nil
+	} else {
+		m.showDetails()
+	}
+	return m.followSpace(space.ID)
+}
 
-	rows := []table.Row{
-		{"name", m.selectedCard.Name},
-		{"x", fmt.Sprintf("%d", m.selectedCard.X)},
-		{"y", fmt.Sprintf("%d", m.selectedCard.Y)},
-		{"backgroundColor", bgColorStyle},
+// loadingHint says which keys work on the loading screen.
+func (m *model) loadingHint() string {
+	if m.opening.ID != "" {
+		return "Press esc to stop waiting, or q to quit."
+	}
+	return "Press q to quit."
+}
+
+// cancelOpen stops waiting for a space that's still loading, leaving the
+// user where they were. It still goes in the cache when it arrives.
+func (m *model) cancelOpen() {
+	m.loading = false
+	m.loadProgress = nil
+	m.opening = Space{}
+	m.pendingLocation = nil
+}
+
+func (m *model) showSpaces() {
+	m.currentView = "list"
+	m.list.Title = "Spaces"
+	if m.spaceSort != spaceSortAPI {
+		m.list.Title += " (" + m.spaceSort.String() + ")"
+	}
+	if m.favoritesOnly {
+		m.list.Title = "Favorite spaces"
+	}
+	items := append([]list.Item{inboxListItem{}}, m.recentSpaceItems()...)
+	favorites, others := m.splitFavorites(m.sortedSpaces())
+	if m.favoritesOnly {
+		others = nil
+	}
+	if len(favorites) > 0 {
+		items = append(items, sectionItem{"Favorites"})
+		for _, space := range favorites {
+			items = append(items, m.spaceItem(space))
+		}
+	}
+	switch m.groupSpaces {
+	case groupPrefix:
+		items = append(items, m.groupedSpaceItems(others)...)
+	case groupKind:
+		items = append(items, m.kindGroupedSpaceItems(others)...)
+	default:
+		if len(items) > 1 && len(others) > 0 {
+			items = append(items, sectionItem{"Spaces"})
+		}
+		for _, space := range others {
+			items = append(items, m.spaceItem(space))
+		}
+	}
+	m.setItems(items)
+}
+
+func (m *model) spaceItem(space Space) listItem {
+	return listItem{Space: space, warm: m.isWarm(space.ID), favorite: m.favorites[space.ID], sortDetail: m.spaceSortDetail(space)}
+}
+
+func (m *model) showDetails() {
+	m.currentView = "details"
+	m.list.Title = m.selectedSpace.Name
+	detailItems := []list.Item{
+		detailListItem{"Cards", plural(len(m.selectedSpace.Cards), "card")},
+		detailListItem{"Boxes", displaySettings.formatNumber(len(m.selectedSpace.Boxes)) + " boxes"},
+		detailListItem{"Connections", plural(len(m.selectedSpace.Connections), "connection")},
+		detailListItem{"Tags", plural(len(spaceTags(m.selectedSpace)), "tag")},
+		detailListItem{"Graph", computeGraphMetrics(m.selectedSpace).summary()},
+	}
+	if m.readOnly() {
+		detailItems = append(detailItems, detailListItem{"Duplicate", "Copy this space into your account to make changes"})
+	} else {
+		detailItems = append(detailItems,
+			detailListItem{"Privacy", privacyDescription(m.selectedSpace.Privacy)},
+			detailListItem{"Collaborators", collaboratorNames(m.selectedSpace)},
+			detailListItem{"Invite", "Copy a link that makes whoever opens it a collaborator"},
+			detailListItem{"Removed cards", "Restore cards removed from this space"},
+			detailListItem{"Actions", "Rename, export, duplicate or delete this space"},
+		)
 	}
+	m.setItems(detailItems)
+}
+
+func (m *model) showBoxes() {
+	m.currentView = "boxes"
+	m.list.Title = m.selectedSpace.Name + " → Boxes"
+	items := make([]list.Item, len(m.selectedSpace.Boxes))
+	for i, box := range m.selectedSpace.Boxes {
+		items[i] = boxListItem{box, len(m.cardsInBox(box))}
+	}
+	m.setItems(items)
+}
+
+func (m *model) showCards() {
+	m.currentView = "cards"
+	m.setCardItems()
+}
+
+// visibleCards returns the selected space's cards, limited to boxFilter
+// and tagFilter when they're set.
+func (m *model) visibleCards() []Card {
+	cards := m.selectedSpace.Cards
+	if m.boxFilter != nil {
+		cards = m.cardsInBox(*m.boxFilter)
+	}
+	if m.tagFilter == "" {
+		return cards
+	}
+	var tagged []Card
+	for _, card := range cards {
+		if m.hasTag(card, m.tagFilter) {
+			tagged = append(tagged, card)
+		}
+	}
+	return tagged
+}
+
+// boxForCard returns the box a card sits in. When boxes are nested the
+// smallest one wins, since that is the tightest grouping on the canvas.
+func (m *model) boxForCard(card Card) (Box, bool) {
+	return cardBox(m.selectedSpace.Boxes, card)
+}
+
+// cardBox returns the smallest of boxes that a card sits in.
+func cardBox(boxes []Box, card Card) (Box, bool) {
+	var found Box
+	ok := false
+	for _, box := range boxes {
+		if !box.Contains(card) {
+			continue
+		}
+		if !ok || box.ResizeWidth*box.ResizeHeight < found.ResizeWidth*found.ResizeHeight {
+			found, ok = box, true
+		}
+	}
+	return found, ok
+}
+
+func (m *model) cardByID(id string) (Card, bool) {
+	for _, card := range m.selectedSpace.Cards {
+		if card.ID == id {
+			return card, true
+		}
+	}
+	return Card{}, false
+}
+
+// userName looks up the name of one of the space's users or collaborators.
+func (m *model) userName(userID string) string {
+	for _, user := range slices.Concat(m.selectedSpace.Users, m.selectedSpace.Collaborators) {
+		if user.ID == userID {
+			return user.Name
+		}
+	}
+	return userID
+}
+
+func (m *model) cardsInBox(box Box) []Card {
+	var cards []Card
+	for _, card := range m.selectedSpace.Cards {
+		if box.Contains(card) {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+func (m *model) setCardTitle() {
+	m.list.Title = m.selectedSpace.Name + " → Cards"
+	if m.boxFilter != nil {
+		m.list.Title = m.selectedSpace.Name + " → " + m.boxFilter.Name + " → Cards"
+	}
+	if m.tagFilter != "" {
+		m.list.Title = m.selectedSpace.Name + " → [[" + m.tagFilter + "]] → Cards"
+	}
+	if m.cardSort != sortReading {
+		m.list.Title += " (" + m.cardSort.String() + ")"
+	}
+	if m.hideComments {
+		m.list.Title += " (comments hidden)"
+	}
+	if m.openTasksOnly {
+		m.list.Title += " (open tasks)"
+	}
+	if n := len(m.selectedCards()); n > 0 {
+		m.list.Title += fmt.Sprintf(" (%d selected)", n)
+	}
+}
+
+// setCardItems fills the list with the visible cards, in the current sort
+// order.
+func (m *model) setCardItems() {
+	m.setCardTitle()
+	cards := m.pinnedFirst(sortCards(m.openTaskFilter(m.commentFilter(m.visibleCards())), m.cardSort))
+	if m.groupByBox && m.boxFilter == nil {
+		m.setItems(m.groupedCardItems(cards))
+		return
+	}
+	width := len(fmt.Sprint(len(cards)))
+	items := make([]list.Item, len(cards))
+	for i, card := range cards {
+		items[i] = m.cardItem(card, i+1, width)
+	}
+	m.setItems(items)
+}
+
+// cardItem makes the list item for a card. number is its position in the
+// list, shown when numbering is on, padded to width digits.
+func (m *model) cardItem(card Card, number, width int) cardListItem {
+	item := cardListItem{Card: card, selected: m.selected[card.ID], pinned: m.isPinned(card.ID), showUpdated: m.showUpdated, changed: m.recentlyChanged(card.ID), matched: m.findMatch(card.Name), sortDetail: m.sortDetail(card)}
+	if box, ok := m.boxForCard(card); ok {
+		item.boxName = box.Name
+	}
+	if m.numberCards {
+		item.number = fmt.Sprintf("%*d  ", width, number)
+	}
+	return item
+}
+
+// jumpToCard moves the cursor to the card number typed into jumpInput.
+func (m *model) jumpToCard() {
+	n, err := strconv.Atoi(m.jumpInput)
+	m.jumpInput = ""
+	if err != nil || n < 1 {
+		return
+	}
+	// Count cards only, skipping any section headers.
+	for i, item := range m.list.Items() {
+		if _, ok := item.(cardListItem); ok {
+			n--
+		}
+		if n == 0 {
+			m.list.ResetFilter()
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+func (m *model) showCardDetails() tea.Cmd {
+	columns := []table.Column{
+		{Title: "Field", Width: 15},
+		{Title: "Value", Width: 65},
+	}
+	rows := m.cardDetailRows()
 
 	m.cardTable = table.New(
 		table.WithColumns(columns),
 		table.WithRows(rows),
 		table.WithFocused(true),
-		table.WithHeight(8),
+		table.WithHeight(len(rows)+1),
 	)
 
 	// Apply styles
 	s := table.DefaultStyles()
 	s.Header = s.Header.
 		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(theme.Border).
 		BorderBottom(true).
 		Bold(false)
 	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
+		Foreground(theme.OnAccent).
+		Background(theme.Accent).
 		Bold(false)
 	m.cardTable.SetStyles(s)
 
 	return nil
 }
 
+// cardDetailRows builds the cardDetails table's rows for the selected card.
+func (m *model) cardDetailRows() []table.Row {
+	// Determine the background color to use
+	bgColor := m.selectedCard.BackgroundColor
+	if bgColor == "" {
+		bgColor = defaultCardColor
+	}
+
+	// Use lipgloss to apply the background color to the cell
+	bgColorStyle := lipgloss.NewStyle().Background(lipgloss.Color(bgColor)).Render(bgColor)
+
+	card := m.selectedCard
+	name := card.Name
+	if m.nameInput != nil {
+		name = m.nameInput.View()
+	}
+	section := func(title string) table.Row {
+		return table.Row{lipgloss.NewStyle().Bold(true).Render(title), ""}
+	}
+	rows := []table.Row{
+		section("Basic"),
+		{"name", name},
+		{"x", fmt.Sprintf("%d", card.X)},
+		{"y", fmt.Sprintf("%d", card.Y)},
+		{"backgroundColor", bgColorStyle},
+		{"comment", fmt.Sprintf("%t", card.IsComment)},
+		section("Content"),
+		{"tags", orNone(strings.Join(cardTags(card.Name), ", "))},
+	}
+	links := cardLinks(card)
+	if len(links) == 0 {
+		rows = append(rows, table.Row{"links", orNone("")})
+	}
+	for _, link := range links {
+		rows = append(rows, table.Row{linkRow, link})
+	}
+	if comments := m.cardCommentRows(card); len(comments) > 0 {
+		rows = append(rows, section("Comments"))
+		rows = append(rows, comments...)
+	}
+	if connections := m.cardConnectionRows(card); len(connections) > 0 {
+		rows = append(rows, section("Connections"))
+		rows = append(rows, connections...)
+	}
+	rows = append(rows,
+		section("History"),
+		table.Row{"createdAt", formatTimestamp(card.CreatedAt)},
+		table.Row{"updatedAt", formatTimestamp(card.UpdatedAt)},
+		table.Row{"nameUpdatedAt", formatTimestamp(card.NameUpdatedAt)},
+		table.Row{"creator", orNone(m.userName(card.UserID))},
+	)
+	if m.showAdvanced {
+		rows = append(rows,
+			section("▾ Advanced"),
+			table.Row{"id", card.ID},
+			table.Row{"z", fmt.Sprintf("%d", card.Z)},
+			table.Row{"width", fmt.Sprintf("%d", card.Width)},
+			table.Row{"height", fmt.Sprintf("%d", card.Height)},
+			table.Row{"frame", fmt.Sprintf("%d", card.FrameID)},
+			table.Row{"counter", fmt.Sprintf("%s (visible: %t)", displaySettings.formatNumber(card.CounterValue), card.CounterIsVisible)},
+			table.Row{"locked", fmt.Sprintf("%t", card.IsLocked)},
+			table.Row{"urlPreviewUrl", orNone(card.URLPreviewURL)},
+		)
+	} else {
+		rows = append(rows, section("▸ Advanced (a to expand)"))
+	}
+
+	return rows
+}
+
 func (m *model) View() string {
 	if m.loading {
-		return fmt.Sprintf("\n\n   %s Loading...\n\nPress q to quit.", m.spinner.View())
+		if m.loadProgress != nil {
+			return m.loadProgressView()
+		}
+		loading := "Loading..."
+		if status := m.retryStatus(); status != "" {
+			loading += " (" + status + ")"
+		}
+		return fmt.Sprintf("\n\n   %s %s\n\n%s", m.spinner.View(), loading, m.loadingHint())
+	}
+	if m.reauth != nil {
+		return lipgloss.NewStyle().Padding(2, 3).Render(m.reauth.View())
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error:\n%v\n\nPress q to quit.", m.err)
+		return m.errorView()
+	}
+	if m.confirm != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.confirm.View())
+	}
+	if m.presentation != nil {
+		return m.presentation.View(m.width, m.height)
+	}
+	if m.switcher != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top, "\n\n"+m.switcher.View(m.width))
+	}
+	if m.search != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top, "\n\n"+m.search.View(m.width))
+	}
+	if m.palette != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top, "\n\n"+m.palette.View(m.width))
+	}
+	if m.colorPicker != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.colorPicker.View())
+	}
+	if m.showHelp {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.helpView())
+	}
+
+	header := ""
+	if m.inSpace() {
+		header = m.headerView() + "\n"
+	}
+	if m.bulkAdd != nil {
+		return header + m.bulkAdd.View()
+	}
+
+	if m.currentView == "kanban" {
+		helpText := m.helpLine()
+		if m.prompt != nil {
+			helpText = "\n" + m.prompt.View()
+		}
+		return header + m.kanban.View(m.width, m.list.Height()) + helpText + "\n" + m.statusBarView()
+	}
+
+	if m.currentView == "canvas" {
+		helpText := m.helpLine()
+		if m.prompt != nil {
+			helpText = "\n" + m.prompt.View()
+		}
+		m.canvas.render(m.width, m.list.Height())
+		return header + m.canvas.viewport.View() + helpText + "\n" + m.statusBarView()
 	}
 
 	if m.currentView == "cardDetails" {
-		return lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).Render(m.cardTable.View()) + "\nPress b to go back."
+		helpText := m.helpLine()
+		if m.nameInput != nil {
+			helpText = "\nPress enter to save the name, esc to cancel."
+		} else if m.copying {
+			helpText = copyHelp
+		}
+		return header + m.cardContentView() + "\n" + lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).Render(m.cardTable.View()) + helpText + "\n" + m.statusBarView()
 	}
 
-	helpText := "\nPress Enter to view details, b to go back, q to quit."
-	return m.list.View() + helpText
+	helpText := m.helpLine()
+	if m.prompt != nil {
+		helpText = "\n" + m.prompt.View()
+	} else if m.copying {
+		helpText = copyHelp
+	} else if m.currentView == "cards" && m.jumpInput != "" {
+		helpText = fmt.Sprintf("\nJump to card: %s (enter to jump, esc to cancel)", m.jumpInput)
+	} else if m.currentView == "cards" && m.find != nil {
+		helpText = "\n" + m.findStatus()
+	}
+	body := m.list.View()
+	if m.splitActive() {
+		body = m.splitView()
+	}
+	return header + body + helpText + "\n" + m.statusBarView()
+}
+
+// loadProgressView shows how much of a large space has arrived, as a bar
+// when the server said how big it is.
+func (m *model) loadProgressView() string {
+	p := m.loadProgress
+	status := fmt.Sprintf("Loading... %d cards", p.cards)
+	if p.total <= 0 {
+		return fmt.Sprintf("\n\n   %s %s\n\n%s", m.spinner.View(), status, m.loadingHint())
+	}
+	bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(40))
+	return fmt.Sprintf("\n\n   %s\n\n   %s\n\n%s", status, bar.ViewAs(float64(p.bytesRead)/float64(p.total)), m.loadingHint())
 }
 
 type listItem struct {
-	Space Space
+	Space      Space
+	warm       bool
+	favorite   bool
+	sortDetail string // What the list is sorted by, e.g. "updated 2 days ago"
+}
+
+func (i listItem) Prefix() string {
+	prefix := ""
+	if i.favorite {
+		prefix += "★ "
+	}
+	if i.warm {
+		prefix += "♨ "
+	}
+	return prefix
 }
 
 func (i listItem) FilterValue() string { return i.Space.Name }
 func (i listItem) Title() string       { return i.Space.Name }
 func (i listItem) Description() string {
-	return fmt.Sprintf("https://kinopio.club/%s", i.Space.Url)
+	desc := fmt.Sprintf("https://kinopio.club/%s", i.Space.Url)
+	if i.sortDetail != "" {
+		desc += " · " + i.sortDetail
+	}
+	return desc
 }
 
 type detailListItem struct {
@@ -234,14 +1454,69 @@ func (i detailListItem) FilterValue() string { return i.title }
 func (i detailListItem) Title() string       { return i.title }
 func (i detailListItem) Description() string { return i.description }
 
+type boxListItem struct {
+	Box       Box
+	cardCount int
+}
+
+func (i boxListItem) FilterValue() string { return i.Box.Name }
+func (i boxListItem) Title() string       { return i.Box.Name }
+func (i boxListItem) Description() string {
+	return fmt.Sprintf("(%d, %d) %d×%d · %s", i.Box.X, i.Box.Y, i.Box.ResizeWidth, i.Box.ResizeHeight, plural(i.cardCount, "card"))
+}
+
 type cardListItem struct {
-	Card Card
+	Card        Card
+	number      string // Set when card numbering is on
+	boxName     string // The box the card sits in, if any
+	selected    bool
+	pinned      bool
+	showUpdated bool
+	changed     bool   // Just changed by a collaborator
+	matched     []int  // Where the card's name matches the find query
+	sortDetail  string // What the list is sorted by, e.g. "by Ana"
+}
+
+func (i cardListItem) Prefix() string {
+	prefix := i.number
+	if i.changed {
+		prefix += "✦ "
+	}
+	if i.selected {
+		prefix += "● "
+	}
+	if i.pinned {
+		prefix += "📌 "
+	}
+	if i.Card.IsComment {
+		prefix += "💬 "
+	}
+	if isTask, done := cardCheckbox(i.Card.Name); isTask {
+		if done {
+			prefix += "✓ "
+		} else {
+			prefix += "☐ "
+		}
+	}
+	return prefix
 }
+func (i cardListItem) Dimmed() bool   { return i.Card.IsComment }
+func (i cardListItem) Swatch() string { return i.Card.BackgroundColor }
 
 func (i cardListItem) FilterValue() string { return i.Card.Name }
 func (i cardListItem) Title() string       { return i.Card.Name }
 func (i cardListItem) Description() string {
-	return fmt.Sprintf("(%d, %d)", i.Card.X, i.Card.Y)
+	desc := fmt.Sprintf("(%d, %d)", i.Card.X, i.Card.Y)
+	if i.boxName != "" {
+		desc += " · in " + i.boxName
+	}
+	if i.showUpdated {
+		desc += " · updated " + relativeTime(i.Card.UpdatedAt)
+	}
+	if i.sortDetail != "" {
+		desc += " · " + i.sortDetail
+	}
+	return desc
 }
 
 type spacesMsg struct {
@@ -252,115 +1527,114 @@ type spaceDetailsMsg struct {
 	Space Space
 }
 
-func fetchSpaces() tea.Cmd {
-	return func() tea.Msg {
-		apiKey := getAPIKey()
-		client := &http.Client{}
-		req, err := http.NewRequest("GET", "https://api.kinopio.club/user/spaces", nil)
-		if err != nil {
-			return fmt.Errorf("error creating request: %v", err)
-		}
-
-		req.Header.Set("Authorization", apiKey)
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return fmt.Errorf("error performing request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("error reading response body: %v", err)
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			var errorDetails map[string]interface{}
-			jsonErr := json.Unmarshal(body, &errorDetails)
-			if jsonErr != nil {
-				return fmt.Errorf("failed to fetch spaces: %s\nResponse body: %s", resp.Status, string(body))
-			}
-			errorDetailsStr, _ := json.MarshalIndent(errorDetails, "", "  ")
-			return fmt.Errorf("failed to fetch spaces: %s\nError details:\n%s", resp.Status, string(errorDetailsStr))
-		}
-
-		var spaces []Space
-		if err := json.Unmarshal(body, &spaces); err != nil {
-			return fmt.Errorf("error unmarshaling response: %v", err)
-		}
-
-		return spacesMsg{spaces: spaces}
+func main() {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
 	}
-}
-
-func fetchSpaceDetails(spaceID string) tea.Cmd {
-	return func() tea.Msg {
-		apiKey := getAPIKey()
-		client := &http.Client{}
-		req, err := http.NewRequest("GET", fmt.Sprintf("https://api.kinopio.club/space/%s", spaceID), nil)
-		if err != nil {
-			return fmt.Errorf("error creating request: %v", err)
-		}
 
-		req.Header.Set("Authorization", apiKey)
-		req.Header.Set("Content-Type", "application/json")
+	// Flags before a subcommand apply to it too, e.g.
+	// `kinopio-tui --base-url ... spaces`.
+	popup := flag.Bool("popup", false, "show just a capture input that adds a card and exits")
+	popupSpace := flag.String("space", "Inbox", "space that --popup adds cards to")
+	flag.StringVar(&cfg.Auth, "auth", cfg.Auth, "where to keep the API key: config or keyring")
+	flag.StringVar(&cfg.BaseURL, "base-url", cfg.BaseURL, "Kinopio API address")
+	flag.StringVar(&cfg.Theme, "theme", cfg.Theme, "color theme: auto, dark, light or high-contrast")
+	flag.DurationVar(&cfg.Refresh, "refresh", cfg.Refresh, "re-fetch the open space or spaces list this often, e.g. 30s")
+	flag.BoolVar(&cfg.Resume, "resume", cfg.Resume, "reopen the space and card you were on when you last quit")
+	flag.BoolVar(&cfg.FavoritesOnly, "favorites-only", cfg.FavoritesOnly, "list only your favorite spaces")
+	flag.DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "give up on a request after this long (default 30s)")
+	flag.Parse()
+	if err := cfg.apply(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	userConfig = cfg
 
-		resp, err := client.Do(req)
-		if err != nil {
-			return fmt.Errorf("error performing request: %v", err)
+	if ran, err := runCommand(flag.Args()); ran {
+		if errors.Is(err, kinopio.ErrNoAPIKey) {
+			err = fmt.Errorf("%v: run kinopio-tui without a command to set one up", err)
 		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("error reading response body: %v", err)
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			var errorDetails map[string]interface{}
-			jsonErr := json.Unmarshal(body, &errorDetails)
-			if jsonErr != nil {
-				return fmt.Errorf("failed to fetch space details: %s\nResponse body: %s", resp.Status, string(body))
-			}
-			errorDetailsStr, _ := json.MarshalIndent(errorDetails, "", "  ")
-			return fmt.Errorf("failed to fetch space details: %s\nError details:\n%s", resp.Status, string(errorDetailsStr))
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
 		}
+		return
+	}
 
-		var space Space
-		if err := json.Unmarshal(body, &space); err != nil {
-			return fmt.Errorf("error unmarshaling space details: %v", err)
+	if *popup {
+		if err := runPopup(*popupSpace); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
 		}
-
-		return spaceDetailsMsg{Space: space}
+		return
 	}
-}
 
-func getAPIKey() string {
-	apiKey := os.Getenv("KINOPIO_API_KEY")
-	if apiKey == "" {
-		fmt.Println("API key is not set")
+	if err := runTUI(false); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
-	return apiKey
 }
 
-func main() {
-	itemDelegate := list.NewDefaultDelegate()
-	l := list.New([]list.Item{}, itemDelegate, 0, 0) // Start with zero size, we'll adjust it later
+// runTUI runs the interactive app, starting on today's journal if journal
+// is set.
+func runTUI(journal bool) error {
+	if api.APIKey == "" {
+		if ok, err := runSetup(); !ok {
+			return err
+		}
+	}
+
+	l := list.New([]list.Item{}, newItemDelegate(), 0, 0) // Start with zero size, we'll adjust it later
 	l.Title = "Spaces"
 	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)        // The help line and ? overlay cover the list's keys
 	l.SetFilteringEnabled(true) // Enable filtering for fuzzy search
 
 	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
 
+	state, err := loadState()
+	if err != nil {
+		return fmt.Errorf("error loading state: %v", err)
+	}
+	if err := writes.load(); err != nil {
+		return err
+	}
+	api.Cache = etagCache{}
+	keymap, err := parseKeybindings(userConfig.Keybindings)
+	if err != nil {
+		return err
+	}
+	startSpace := userConfig.DefaultSpace
+	if journal {
+		startSpace = ""
+	}
+
 	m := &model{
-		list:    l,
-		spinner: sp,
+		list:          l,
+		spinner:       sp,
+		state:         state,
+		spaceCache:    map[string]Space{},
+		liveChanged:   map[string]time.Time{},
+		refreshEvery:  userConfig.Refresh,
+		selected:      map[string]bool{},
+		journal:       journal,
+		startSpace:    startSpace,
+		keymap:        keymap,
+		nudgeStep:     initialNudgeStep(),
+		favoritesOnly: userConfig.FavoritesOnly,
+		resume:        userConfig.Resume && !journal,
 	}
-	p := tea.NewProgram(m, tea.WithAltScreen()) // Use alternate screen buffer to clear screen
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintln(os.Stderr, "Error running program:", err)
-		os.Exit(1)
+	m.restyle()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	programCtx = ctx
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx)) // Use alternate screen buffer to clear screen
+	api.OnRetry = func(r kinopio.Retry) { p.Send(retryingMsg{r}) }
+	final, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("error running program: %v", err)
 	}
+	return final.(*model).saveSession()
 }
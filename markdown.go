@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// maxContentLines is how much of a card's rendered text cardDetails shows.
+const maxContentLines = 12
+
+// rendererKey identifies a glamour renderer by its style and width.
+type rendererKey struct {
+	style string
+	width int
+}
+
+// markdownRenderers keeps renderers, which are slow to set up, for the
+// styles and widths they've been made for.
+var markdownRenderers = map[rendererKey]*glamour.TermRenderer{}
+
+// markdownStyle picks the glamour style that suits the terminal: plain on
+// terminals without color, or else dark or light for its background.
+func markdownStyle() string {
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return "notty"
+	}
+	if lipgloss.HasDarkBackground() {
+		return "dark"
+	}
+	return "light"
+}
+
+// renderMarkdown renders a card's text as markdown wrapped to width, with
+// a task's checkbox shown as the cards list shows it. If the text can't be
+// rendered it comes back as it is.
+func renderMarkdown(text string, width int) string {
+	if isTask, done := cardCheckbox(text); isTask {
+		box := "☐ "
+		if done {
+			box = "✓ "
+		}
+		text = box + checkboxPattern.ReplaceAllString(text, "")
+	}
+	r := rendererKey{style: markdownStyle(), width: width}
+	renderer, ok := markdownRenderers[r]
+	if !ok {
+		var err error
+		renderer, err = glamour.NewTermRenderer(glamour.WithStandardStyle(r.style), glamour.WithWordWrap(width))
+		if err != nil {
+			return text
+		}
+		markdownRenderers[r] = renderer
+	}
+	rendered, err := renderer.Render(text)
+	if err != nil {
+		return text
+	}
+	return strings.Trim(rendered, "\n")
+}
+
+// cardText is a card's text for the detail and preview views: rendered
+// markdown, or the raw text when that's toggled on.
+func (m *model) cardText(text string, width int) string {
+	if m.rawText {
+		return lipgloss.NewStyle().Width(width).Render(text)
+	}
+	return renderMarkdown(text, width)
+}
+
+// cardContentView shows the open card's text above the cardDetails table,
+// cut short if it's long.
+func (m *model) cardContentView() string {
+	width := min(m.width-2, 80)
+	lines := strings.Split(m.cardText(m.selectedCard.Name, width), "\n")
+	if len(lines) > maxContentLines {
+		lines = append(lines[:maxContentLines], dimStyle().Render("…"))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+)
+
+var (
+	nameAdjectives = []string{
+		"bouncy", "cozy", "dreamy", "fizzy", "fluffy", "gentle", "glowing",
+		"jolly", "lucky", "mellow", "misty", "mossy", "nimble", "peppy",
+		"plucky", "quiet", "rosy", "shiny", "snug", "sparkly", "spry",
+		"sunny", "swirly", "tidy", "wiggly", "windy", "wobbly", "zesty",
+	}
+	nameNouns = []string{
+		"acorn", "badger", "biscuit", "bumblebee", "cloud", "comet",
+		"dumpling", "fern", "firefly", "garden", "hedgehog", "kite",
+		"lantern", "meadow", "moon", "muffin", "noodle", "otter", "pebble",
+		"pickle", "puddle", "radish", "sprout", "teapot", "toadstool",
+		"tofu", "turnip", "walrus",
+	}
+)
+
+// randomName generates a whimsical default name like "mossy-teapot", for
+// creating spaces without typing a name.
+func randomName() string {
+	return strings.Join([]string{
+		nameAdjectives[rand.Intn(len(nameAdjectives))],
+		nameNouns[rand.Intn(len(nameNouns))],
+	}, "-")
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notificationsMsg carries the user's notifications, and whether to show
+// them or just count the unread ones.
+type notificationsMsg struct {
+	notifications []Notification
+	show          bool
+}
+
+// fetchNotifications fetches the user's notifications. Fetched to count
+// them, the status bar just goes without a count if that fails.
+func fetchNotifications(show bool) tea.Cmd {
+	return func() tea.Msg {
+		notifications, err := api.Notifications(programCtx)
+		if err != nil && !show {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return notificationsMsg{notifications: notifications, show: show}
+	}
+}
+
+// unreadNotifications counts the notifications not yet seen.
+func (m *model) unreadNotifications() int {
+	n := 0
+	for _, notification := range m.notifications {
+		if !notification.IsRead {
+			n++
+		}
+	}
+	return n
+}
+
+// openNotifications shows the notifications, fetching them afresh.
+func (m *model) openNotifications() tea.Cmd {
+	m.loading = true
+	return tea.Batch(fetchNotifications(true), m.spinner.Tick)
+}
+
+// showNotifications lists the notifications, then marks them read. They
+// keep their unread markers until the list is next shown.
+func (m *model) showNotifications() tea.Cmd {
+	m.currentView = "notifications"
+	m.list.Title = "Notifications"
+	items := make([]list.Item, len(m.notifications))
+	for i, notification := range m.notifications {
+		items[i] = notificationItem{notification}
+	}
+	m.setItems(items)
+	if m.unreadNotifications() == 0 {
+		return nil
+	}
+	for i := range m.notifications {
+		m.notifications[i].IsRead = true
+	}
+	return apiCmd(func(ctx context.Context) error { return api.MarkNotificationsRead(ctx) })
+}
+
+// openNotification goes to the card or space a notification is about.
+func (m *model) openNotification(n Notification) tea.Cmd {
+	if n.SpaceID == "" {
+		return nil
+	}
+	space := Space{ID: n.SpaceID}
+	for _, s := range m.spaces {
+		if s.ID == n.SpaceID {
+			space = s
+		}
+	}
+	if n.CardID != "" {
+		m.pendingLocation = &location{view: "cardDetails", spaceID: n.SpaceID, cardID: n.CardID}
+	}
+	return m.openSpace(space)
+}
+
+type notificationItem struct {
+	Notification Notification
+}
+
+func (i notificationItem) Prefix() string {
+	if !i.Notification.IsRead {
+		return "● "
+	}
+	return ""
+}
+
+func (i notificationItem) FilterValue() string { return i.Title() }
+func (i notificationItem) Title() string {
+	n := i.Notification
+	who, where := orNone(n.User.Name), orNone(n.Space.Name)
+	switch n.Type {
+	case "addCard":
+		return fmt.Sprintf("%s added a card to %s", who, where)
+	case "updateCard":
+		return fmt.Sprintf("%s updated a card in %s", who, where)
+	case "inviteUserToSpace":
+		return fmt.Sprintf("%s invited you to %s", who, where)
+	case "addSpaceToExplore":
+		return fmt.Sprintf("%s was added to Explore", where)
+	}
+	return fmt.Sprintf("%s: %s in %s", who, n.Type, where)
+}
+func (i notificationItem) Description() string {
+	desc := relativeTime(i.Notification.CreatedAt)
+	if name := i.Notification.Card.Name; name != "" {
+		desc = firstLine(name) + " · " + desc
+	}
+	return desc
+}
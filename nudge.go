@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// nudgeSteps are the distances, in canvas pixels, that [ and ] cycle
+// through. KINOPIO_NUDGE_STEP sets the starting one.
+var nudgeSteps = []int{1, 10, 50, 100}
+
+const (
+	defaultNudgeStep = 10
+	// nudgeSaveDelay is how long a card has to stay put after a nudge
+	// before its position is saved, so holding a key sends one request.
+	nudgeSaveDelay = 500 * time.Millisecond
+)
+
+type nudgeSaveMsg struct {
+	cardID string
+	seq    int
+}
+
+// initialNudgeStep reads KINOPIO_NUDGE_STEP, falling back to the default.
+func initialNudgeStep() int {
+	if step, err := strconv.Atoi(os.Getenv("KINOPIO_NUDGE_STEP")); err == nil && step > 0 {
+		return step
+	}
+	return defaultNudgeStep
+}
+
+// cycleNudgeStep moves to the next larger or smaller nudge step.
+func (m *model) cycleNudgeStep(dir int) {
+	i, found := slices.BinarySearch(nudgeSteps, m.nudgeStep)
+	if !found && dir > 0 {
+		i--
+	}
+	i = max(0, min(len(nudgeSteps)-1, i+dir))
+	m.nudgeStep = nudgeSteps[i]
+}
+
+// nudgeCard moves the open card by dx, dy steps. The move shows right away
+// and is saved once the card stops moving.
+func (m *model) nudgeCard(dx, dy int) tea.Cmd {
+	card := m.selectedCard
+	card.X += dx * m.nudgeStep
+	card.Y += dy * m.nudgeStep
+	cursor := m.cardTable.Cursor()
+	m.recordMove(card)
+	m.replaceCards(card)
+	m.cardTable.SetCursor(cursor)
+
+	m.nudgeSeq++
+	msg := nudgeSaveMsg{cardID: card.ID, seq: m.nudgeSeq}
+	return tea.Tick(nudgeSaveDelay, func(time.Time) tea.Msg { return msg })
+}
+
+// saveNudge saves a nudged card's position if it hasn't moved again since.
+func (m *model) saveNudge(msg nudgeSaveMsg) tea.Cmd {
+	if msg.seq != m.nudgeSeq {
+		return nil
+	}
+	card, ok := m.cardByID(msg.cardID)
+	if !ok {
+		return nil
+	}
+	return updateCard(map[string]interface{}{"id": card.ID, "x": card.X, "y": card.Y})
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// outlineIndent is how far right, in canvas pixels, each level of a
+// nested list is placed.
+const outlineIndent = 30
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	bulletPattern   = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(.*)$`)
+	taskPattern     = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	footnotePattern = regexp.MustCompile(`\s*\[\^[^\]]+\]`)
+)
+
+// outline is a Markdown or plain text document parsed into cards: one
+// section per heading, plus one before the first heading. Its title is the
+// first top-level heading, if it starts with one.
+type outline struct {
+	title    string
+	sections []outlineSection
+}
+
+type outlineSection struct {
+	name  string
+	items []outlineItem
+}
+
+type outlineItem struct {
+	text  string
+	depth int // How deeply nested the list item is
+}
+
+// parseOutline reads list items as cards, turning "- [ ]" and "- [x]"
+// into task cards, and indented lines under an item into more lines of
+// it. Other lines of text become a card each. Footnotes, as the Markdown
+// export writes for connections, are dropped.
+func parseOutline(text string) outline {
+	doc := outline{sections: []outlineSection{{}}}
+	section := func() *outlineSection { return &doc.sections[len(doc.sections)-1] }
+	inItem := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "[^") {
+			continue // A footnote's definition
+		}
+		line = footnotePattern.ReplaceAllString(strings.TrimRight(line, " \t"), "")
+		if strings.TrimSpace(line) == "" {
+			inItem = false
+			continue
+		}
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			inItem = false
+			if len(match[1]) == 1 && doc.title == "" && len(doc.sections) == 1 && len(section().items) == 0 {
+				doc.title = match[2]
+				continue
+			}
+			doc.sections = append(doc.sections, outlineSection{name: match[2]})
+			continue
+		}
+		if match := bulletPattern.FindStringSubmatch(line); match != nil {
+			name := match[2]
+			if task := taskPattern.FindStringSubmatch(name); task != nil {
+				name = setCheckbox(taskPattern.ReplaceAllString(name, ""), task[1] != " ")
+			}
+			depth := len(strings.ReplaceAll(match[1], "\t", "  ")) / 2
+			section().items = append(section().items, outlineItem{text: name, depth: depth})
+			inItem = true
+			continue
+		}
+		if inItem && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			items := section().items
+			items[len(items)-1].text += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		section().items = append(section().items, outlineItem{text: strings.TrimSpace(line)})
+		inItem = false
+	}
+	return doc
+}
+
+// layoutOutline places an outline's cards like layoutColumns does, one
+// column per section, with nested items indented.
+func layoutOutline(doc outline) ([]Box, []Card) {
+	var columns []column
+	var depths []int
+	for _, section := range doc.sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		c := column{name: section.name}
+		for _, item := range section.items {
+			c.cards = append(c.cards, item.text)
+			depths = append(depths, item.depth)
+		}
+		columns = append(columns, c)
+	}
+	boxes, cards := layoutColumns(columns)
+	for i := range cards {
+		cards[i].X += depths[i] * outlineIndent
+	}
+	return boxes, cards
+}
+
+// runImportFile imports a Markdown or plain text file into a space, or
+// into a new space named after the document's title.
+func runImportFile(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	spaceName := fs.String("space", "", "space to import into (default: a new space named after the document)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: kinopio-tui import <file, or - for stdin> [--space <name or id>]")
+	}
+	path := positional[0]
+
+	var data []byte
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+	doc := parseOutline(string(data))
+	boxes, cards := layoutOutline(doc)
+	if len(cards) == 0 {
+		fmt.Printf("%s has nothing to import\n", path)
+		return nil
+	}
+
+	ctx := context.Background()
+	var space Space
+	if *spaceName != "" {
+		space, err = findSpace(*spaceName)
+	} else {
+		name := doc.title
+		if name == "" && path != "-" {
+			name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if name == "" {
+			name = "Imported"
+		}
+		space, err = api.CreateSpace(ctx, newID(), name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := postBoxes(ctx, space.ID, boxes); err != nil {
+		return err
+	}
+	if err := api.CreateCards(ctx, space.ID, cards); err != nil {
+		return err
+	}
+	fmt.Printf("Imported %s into %s (%s)\n", plural(len(cards), "card"), space.Name, spaceURL(space))
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOutline(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want outline
+	}{
+		{
+			name: "title and sections",
+			text: "# Plans\n\nIntro\n\n## Today\n- one\n- two\n",
+			want: outline{title: "Plans", sections: []outlineSection{
+				{items: []outlineItem{{text: "Intro"}}},
+				{name: "Today", items: []outlineItem{{text: "one"}, {text: "two"}}},
+			}},
+		},
+		{
+			name: "a heading after text isn't the title",
+			text: "Intro\n# Later\n- item",
+			want: outline{sections: []outlineSection{
+				{items: []outlineItem{{text: "Intro"}}},
+				{name: "Later", items: []outlineItem{{text: "item"}}},
+			}},
+		},
+		{
+			name: "nested items",
+			text: "- top\n  - child\n    - grandchild\n\t- tabbed",
+			want: outline{sections: []outlineSection{{items: []outlineItem{
+				{text: "top"}, {text: "child", depth: 1}, {text: "grandchild", depth: 2}, {text: "tabbed", depth: 1},
+			}}}},
+		},
+		{
+			name: "tasks",
+			text: "- [ ] open\n- [x] done\n* [X] also done",
+			want: outline{sections: []outlineSection{{items: []outlineItem{
+				{text: "[] open"}, {text: "[x] done"}, {text: "[x] also done"},
+			}}}},
+		},
+		{
+			name: "continuation lines and numbered items",
+			text: "1. first\n   more of it\n2) second\nplain line",
+			want: outline{sections: []outlineSection{{items: []outlineItem{
+				{text: "first\nmore of it"}, {text: "second"}, {text: "plain line"},
+			}}}},
+		},
+		{
+			name: "footnotes are dropped",
+			text: "- links to[^1]\r\n\r\n[^1]: other card",
+			want: outline{sections: []outlineSection{{items: []outlineItem{{text: "links to"}}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseOutline(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseOutline(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutOutline(t *testing.T) {
+	doc := parseOutline("- top\n  - child\n## Box\n- boxed")
+	boxes, cards := layoutOutline(doc)
+	if len(boxes) != 1 || boxes[0].Name != "Box" {
+		t.Fatalf("boxes = %+v, want one named Box", boxes)
+	}
+	if len(cards) != 3 {
+		t.Fatalf("got %d cards, want 3", len(cards))
+	}
+	if cards[1].X-cards[0].X != outlineIndent {
+		t.Errorf("child is %dpx right of its parent, want %d", cards[1].X-cards[0].X, outlineIndent)
+	}
+	if cards[1].Y <= cards[0].Y {
+		t.Errorf("child at y=%d isn't below its parent at y=%d", cards[1].Y, cards[0].Y)
+	}
+	if cards[2].X <= boxes[0].X || cards[2].Y <= boxes[0].Y {
+		t.Errorf("boxed card at (%d, %d) isn't inside its box at (%d, %d)", cards[2].X, cards[2].Y, boxes[0].X, boxes[0].Y)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// runSpaces lists the user's spaces, one per line as "id<tab>name", or as
+// JSON with --json.
+func runSpaces(args []string) error {
+	fs := flag.NewFlagSet("spaces", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the spaces as JSON")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("usage: kinopio-tui spaces [--json]")
+	}
+
+	spaces, err := api.Spaces(context.Background())
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		if spaces == nil {
+			spaces = []Space{}
+		}
+		return printJSON(spaces)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, space := range spaces {
+		fmt.Fprintf(w, "%s\t%s\n", space.ID, space.Name)
+	}
+	return w.Flush()
+}
+
+// runCards lists a space's cards, one per line as "id<tab>name", or as
+// JSON with --json.
+func runCards(args []string) error {
+	fs := flag.NewFlagSet("cards", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the cards as JSON")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: kinopio-tui cards <space name or id> [--json]")
+	}
+
+	found, err := findSpace(positional[0])
+	if err != nil {
+		return err
+	}
+	space, err := api.Space(context.Background(), found.ID)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		cards := space.Cards
+		if cards == nil {
+			cards = []Card{}
+		}
+		return printJSON(cards)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, card := range space.Cards {
+		// Keep multi-line cards on one line, so each line is one card.
+		fmt.Fprintf(w, "%s\t%s\n", card.ID, strings.Join(strings.Fields(card.Name), " "))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+const paletteMaxResults = 12
+
+// paletteCommand is an action offered in the command palette. Most do
+// what a key does in the views where that key works, so pressing the key
+// and picking the command can't drift apart; the rest run a function.
+type paletteCommand struct {
+	name    string
+	binding key.Binding          // The key that does the same, also shown as a hint
+	run     func(*model) tea.Cmd // Runs instead of pressing the key, if set
+	when    func(*model) bool    // Whether it applies to the current view; nil means always
+}
+
+// inView makes a paletteCommand.when for commands that work in the given
+// views.
+func inView(views ...string) func(*model) bool {
+	return func(m *model) bool {
+		for _, view := range views {
+			if m.currentView == view {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+var paletteCommands = []paletteCommand{
+	{name: "Open space…", binding: keys.SwitchSpace},
+	{name: "Notifications", binding: keys.Notifications},
+	{name: "Search cards in all spaces", binding: keys.Search, when: inView("list")},
+	{name: "New space", binding: keys.New, when: inView("list")},
+	{name: "New randomly named space", binding: keys.NewRandom, when: inView("list")},
+	{name: "Keep space warm", binding: keys.Warm, when: inView("list")},
+	{name: "Group spaces by name or kind", binding: keys.Group, when: inView("list")},
+	{name: "Sort spaces", binding: keys.Sort, when: inView("list")},
+	{name: "Toggle favorite space", binding: keys.Favorite, when: inView("list")},
+	{name: "Explore public spaces", binding: keys.Explore, when: inView("list")},
+	{name: "Account", binding: keys.Account, when: inView("list")},
+	{name: "Browse tags", binding: keys.Tags, when: inView("list")},
+	{name: "Find cards", binding: keys.Find, when: inView("cards")},
+	{name: "Create card", binding: keys.New, when: inView("cards")},
+	{name: "Add many cards", binding: keys.BulkAdd, when: inView("cards")},
+	{name: "Remove card", binding: keys.Delete, when: inView("cards")},
+	{name: "Edit card name", binding: keys.Edit, when: inView("cardDetails")},
+	{name: "Toggle advanced fields", binding: keys.Advanced, when: inView("cardDetails")},
+	{name: "Edit card in $EDITOR", binding: keys.ExternalEdit, when: inView("cards", "cardDetails")},
+	{name: "Edit space as text in $EDITOR", binding: keys.EditSpace, when: inView("cards")},
+	{name: "Toggle raw card text", binding: keys.RawText, when: func(m *model) bool {
+		return m.currentView == "cardDetails" || (m.currentView == "cards" && m.splitActive())
+	}},
+	{name: "Check or uncheck task", binding: keys.Task, when: inView("cards", "cardDetails")},
+	{name: "Add comment", binding: keys.New, when: inView("cardDetails")},
+	{name: "Toggle comment", binding: keys.Comment, when: inView("cards", "cardDetails")},
+	{name: "Pin card", binding: keys.Pin, when: inView("cards")},
+	{name: "Change card color", binding: keys.Color, when: inView("cards", "cardDetails")},
+	{name: "Open link", binding: keys.Open, when: inView("cardDetails")},
+	{name: "Copy card text", binding: key.NewBinding(key.WithHelp("y y", "")), run: (*model).copyCardText, when: inView("cards", "cardDetails")},
+	{name: "Copy card link", binding: key.NewBinding(key.WithHelp("y l", "")), run: (*model).copyCardLink, when: inView("cards", "cardDetails")},
+	{name: "Copy space URL", binding: keys.Yank, when: func(m *model) bool {
+		return m.currentView == "list" || (m.inSpace() && m.currentView != "cards" && m.currentView != "cardDetails")
+	}},
+	{name: "Open space in Kinopio", binding: keys.Open, when: func(m *model) bool {
+		return m.currentView == "list" || (m.inSpace() && m.currentView != "cardDetails")
+	}},
+	{name: "Open card in Kinopio", binding: keys.OpenInKinopio, when: inView("cards", "cardDetails")},
+	{name: "Select matching cards", binding: keys.SelectMatching, when: inView("cards")},
+	{name: "Bulk actions", binding: keys.BulkActions, when: inView("cards")},
+	{name: "Move cards to another space", binding: keys.Move, when: inView("cards", "cardDetails")},
+	{name: "Copy cards to another space", binding: keys.Copy, when: inView("cards", "cardDetails")},
+	{name: "Sort cards", binding: keys.Sort, when: inView("cards")},
+	{name: "Toggle grouping by box", binding: keys.Group, when: inView("cards")},
+	{name: "Toggle card numbers", binding: keys.NumberCards, when: inView("cards")},
+	{name: "Toggle hiding comments", binding: keys.HideComments, when: inView("cards")},
+	{name: "Toggle showing open tasks only", binding: keys.OpenTasks, when: inView("cards")},
+	{name: "Toggle updated times", binding: keys.ShowUpdated, when: inView("cards")},
+	{name: "Kanban view", binding: keys.Kanban, when: inView("cards")},
+	{name: "Canvas view", binding: keys.Canvas, when: inView("cards")},
+	{name: "Present", binding: keys.Present, when: inView("cards")},
+	{name: "Arrange cards in a grid", binding: keys.Arrange, when: inView("cards")},
+	{name: "Import bookmarks", binding: keys.ImportMarks, when: inView("cards")},
+	{name: "Find duplicate cards", binding: keys.Duplicates, when: inView("cards")},
+	{name: "Check links", binding: keys.CheckLinks, when: inView("cards")},
+	{name: "Archive links", binding: keys.Archive, when: inView("cards", "cardDetails")},
+	{name: "Export space to Markdown", run: (*model).exportSpace, when: (*model).inSpace},
+	{name: "Export graph", binding: keys.ExportGraph, when: inView("details", "connections", "graph")},
+	{name: "Undo", binding: keys.Undo, when: (*model).inSpace},
+	{name: "Redo", binding: keys.Redo, when: (*model).inSpace},
+	{name: "Add to inbox", binding: keys.Inbox},
+	{name: "Open today's journal", binding: keys.Journal},
+	{name: "Refresh", binding: keys.Refresh, when: func(m *model) bool { return m.currentView != "removed" }},
+	{name: "Go back", binding: keys.HistoryBack},
+	{name: "Go forward", binding: keys.HistoryForward},
+	{name: "Toggle preview pane", binding: keys.Split, when: inView("list", "cards")},
+	{name: "Switch theme", run: (*model).switchTheme},
+	{name: "Quit", binding: keys.Quit},
+}
+
+// commandPalette is an overlay listing every action that applies to the
+// current view, filtered by fuzzy-matching their names.
+type commandPalette struct {
+	input    textinput.Model
+	commands []paletteCommand
+	matches  fuzzy.Matches
+	cursor   int
+}
+
+// openPalette opens the command palette with the commands for the
+// current view.
+func (m *model) openPalette() tea.Cmd {
+	input := textinput.New()
+	input.Placeholder = "Run a command…"
+	input.Focus()
+	p := &commandPalette{input: input}
+	for _, c := range paletteCommands {
+		if c.when == nil || c.when(m) {
+			p.commands = append(p.commands, c)
+		}
+	}
+	p.filter()
+	m.palette = p
+	return textinput.Blink
+}
+
+func (p *commandPalette) String(i int) string { return p.commands[i].name }
+func (p *commandPalette) Len() int            { return len(p.commands) }
+
+// filter recomputes the matches for the current query. An empty query
+// matches every command in its original order.
+func (p *commandPalette) filter() {
+	query := p.input.Value()
+	if query == "" {
+		p.matches = make(fuzzy.Matches, len(p.commands))
+		for i, c := range p.commands {
+			p.matches[i] = fuzzy.Match{Str: c.name, Index: i}
+		}
+	} else {
+		p.matches = fuzzy.FindFrom(query, p)
+	}
+	p.cursor = 0
+}
+
+// Update handles a key press. It reports whether the palette is finished
+// and, if a command was picked, which one.
+func (p *commandPalette) Update(msg tea.KeyMsg) (bool, *paletteCommand, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		return true, nil, nil
+	case "enter":
+		if len(p.matches) == 0 {
+			return true, nil, nil
+		}
+		return true, &p.commands[p.matches[p.cursor].Index], nil
+	case "up":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return false, nil, nil
+	case "down", "ctrl+n":
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+		return false, nil, nil
+	}
+	var cmd tea.Cmd
+	query := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != query {
+		p.filter()
+	}
+	return false, nil, cmd
+}
+
+func (p *commandPalette) View(width int) string {
+	width = min(60, width-4)
+	normal := lipgloss.NewStyle().Padding(0, 1)
+	selected := normal.Foreground(theme.OnAccent).Background(theme.Accent)
+	match := lipgloss.NewStyle().Underline(true).Bold(true)
+	dim := dimStyle()
+
+	// Scroll to keep the cursor in view.
+	first := max(p.cursor-paletteMaxResults+1, 0)
+	lines := []string{p.input.View(), ""}
+	for i, m := range p.matches {
+		if i < first {
+			continue
+		}
+		if i >= first+paletteMaxResults {
+			break
+		}
+		style := normal
+		if i == p.cursor {
+			style = selected
+		}
+		name := lipgloss.StyleRunes(m.Str, m.MatchedIndexes, match.Inherit(style.Inline(true)), style.Inline(true))
+		hint := p.commands[m.Index].binding.Help().Key
+		gap := max(width-6-lipgloss.Width(m.Str)-lipgloss.Width(hint), 1)
+		name += style.Inline(true).Render(strings.Repeat(" ", gap)) + dim.Inherit(style.Inline(true)).Render(hint)
+		lines = append(lines, style.Width(width-4).MaxWidth(width-4).Render(name))
+	}
+	if len(p.matches) == 0 {
+		lines = append(lines, dim.Render("No matching commands"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// updatePalette forwards a key press to the palette and runs the chosen
+// command.
+func (m *model) updatePalette(msg tea.KeyMsg) tea.Cmd {
+	done, command, cmd := m.palette.Update(msg)
+	if !done {
+		return cmd
+	}
+	m.palette = nil
+	if command == nil {
+		return nil
+	}
+	if command.run != nil {
+		return command.run(m)
+	}
+	press, _ := parseKey(command.binding.Keys()[0])
+	return m.update(press)
+}
+
+// switchTheme flips between the light and dark color themes, keeping the
+// colors the config file overrides.
+func (m *model) switchTheme() tea.Cmd {
+	name := "dark"
+	if lipgloss.HasDarkBackground() {
+		name = "light"
+	}
+	p, _ := themeNamed(name)
+	setTheme(p.with(userConfig.Colors))
+	m.restyle()
+	return m.toast("Switched to the " + name + " theme")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *model) isPinned(cardID string) bool {
+	return slices.Contains(m.state.Pins[m.selectedSpace.ID], cardID)
+}
+
+// togglePin pins the highlighted card to the top of its space's list, or
+// unpins it.
+func (m *model) togglePin() tea.Cmd {
+	item, ok := m.list.SelectedItem().(cardListItem)
+	if !ok {
+		return nil
+	}
+	if m.state.Pins == nil {
+		m.state.Pins = map[string][]string{}
+	}
+	spaceID, cardID := m.selectedSpace.ID, item.Card.ID
+	pins := m.state.Pins[spaceID]
+	if i := slices.Index(pins, cardID); i >= 0 {
+		pins = slices.Delete(pins, i, i+1)
+	} else {
+		pins = append(pins, cardID)
+	}
+	if len(pins) == 0 {
+		delete(m.state.Pins, spaceID)
+	} else {
+		m.state.Pins[spaceID] = pins
+	}
+	m.setCardItems()
+	if err := m.state.save(); err != nil {
+		return func() tea.Msg { return err }
+	}
+	return nil
+}
+
+// pinnedFirst moves pinned cards to the front, keeping the order within
+// the pinned and unpinned groups.
+func (m *model) pinnedFirst(cards []Card) []Card {
+	var pinned, rest []Card
+	for _, card := range cards {
+		if m.isPinned(card.ID) {
+			pinned = append(pinned, card)
+		} else {
+			rest = append(rest, card)
+		}
+	}
+	return append(pinned, rest...)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// popupModel is a single-line capture input sized for a tmux
+// display-popup: type, press enter, and the card is created.
+type popupModel struct {
+	space   string
+	input   textinput.Model
+	spinner spinner.Model
+	saving  bool
+	card    Card
+	saved   Space
+	err     error
+}
+
+type popupSavedMsg struct {
+	space Space
+	card  Card
+}
+
+func newPopupModel(space string) *popupModel {
+	input := textinput.New()
+	input.Prompt = "＋ "
+	input.Placeholder = "Add a card to " + space
+	input.Focus()
+	return &popupModel{
+		space:   space,
+		input:   input,
+		spinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
+	}
+}
+
+func (m *popupModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *popupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case popupSavedMsg:
+		m.saved, m.card = msg.space, msg.card
+		return m, tea.Quit
+	case error:
+		m.err = msg
+		return m, tea.Quit
+	case tea.WindowSizeMsg:
+		m.input.Width = msg.Width - 4
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		case "enter":
+			if m.saving || m.input.Value() == "" {
+				return m, nil
+			}
+			m.saving = true
+			space, name := m.space, m.input.Value()
+			return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+				saved, card, err := addCard(space, name)
+				if err != nil {
+					return err
+				}
+				return popupSavedMsg{space: saved, card: card}
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.saving {
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *popupModel) View() string {
+	if m.saving {
+		return fmt.Sprintf("%s Adding to %s…", m.spinner.View(), m.space)
+	}
+	hint := dimStyle().Render("enter to add · esc to cancel")
+	return m.input.View() + "\n" + hint
+}
+
+// runPopup runs the capture input and prints what happened once it exits.
+func runPopup(space string) error {
+	m := newPopupModel(space)
+	if _, err := tea.NewProgram(m).Run(); err != nil {
+		return err
+	}
+	if m.err != nil {
+		return m.err
+	}
+	if m.card.ID != "" {
+		fmt.Printf("Added to %s: %s\n", m.saved.Name, cardURL(m.saved, m.card))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"slices"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	prefetchCount   = 5  // How many spaces to prefetch after the list loads
+	prefetchWorkers = 3  // How many of them to fetch at once
+	recentSpacesMax = 10 // How many visited spaces to remember
+)
+
+// spacePrefetchedMsg delivers one prefetched space. next waits for the
+// following one.
+type spacePrefetchedMsg struct {
+	Space Space
+	next  tea.Cmd
+}
+
+// prefetchSpaces fetches the recently visited spaces, then the ones at the
+// top of the list, into the space cache so opening them is instant. Spaces
+// already cached are skipped.
+func (m *model) prefetchSpaces() tea.Cmd {
+	var ids []string
+	add := func(id string) {
+		if len(ids) >= prefetchCount || slices.Contains(ids, id) {
+			return
+		}
+		if _, ok := m.spaceCache[id]; !ok && !m.isWarm(id) {
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range m.state.RecentSpaces {
+		if slices.ContainsFunc(m.spaces, func(s Space) bool { return s.ID == id }) {
+			add(id)
+		}
+	}
+	for _, space := range m.spaces {
+		add(space.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return prefetch(ids)
+}
+
+// prefetch fetches spaces with a small pool of workers, delivering each as
+// it arrives. Failures are ignored: the space is simply fetched normally
+// when opened.
+func prefetch(ids []string) tea.Cmd {
+	results := make(chan tea.Msg)
+	var next tea.Cmd
+	next = func() tea.Msg {
+		msg, ok := <-results
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+	return func() tea.Msg {
+		jobs := make(chan string)
+		var wg sync.WaitGroup
+		for range min(prefetchWorkers, len(ids)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for id := range jobs {
+					space, err := api.Space(programCtx, id)
+					if err == nil {
+						results <- spacePrefetchedMsg{Space: space, next: next}
+					}
+				}
+			}()
+		}
+		go func() {
+			for _, id := range ids {
+				jobs <- id
+			}
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+		return next()
+	}
+}
+
+// visitSpace remembers that a space was opened, for prefetching it next
+// time. The state is saved on a best-effort basis.
+func (m *model) visitSpace(spaceID string) {
+	recent := slices.DeleteFunc(m.state.RecentSpaces, func(id string) bool { return id == spaceID })
+	recent = append([]string{spaceID}, recent...)
+	if len(recent) > recentSpacesMax {
+		recent = recent[:recentSpacesMax]
+	}
+	if slices.Equal(recent, m.state.RecentSpaces) {
+		return
+	}
+	m.state.RecentSpaces = recent
+	m.state.save()
+}
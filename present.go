@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// presentation steps through a space's cards one at a time, full screen,
+// like a slide deck.
+type presentation struct {
+	cards         []Card // Cards in reading order
+	connections   []Connection
+	byConnections bool // Follow connections instead of reading order
+	slides        []Card
+	index         int
+}
+
+func newPresentation(cards []Card, connections []Connection) *presentation {
+	p := &presentation{cards: sortCards(cards, sortReading), connections: connections}
+	p.slides = p.cards
+	return p
+}
+
+// toggleOrder switches between reading order and following connections,
+// staying on the current card.
+func (p *presentation) toggleOrder() {
+	current := p.slides[p.index].ID
+	p.byConnections = !p.byConnections
+	p.slides = p.cards
+	if p.byConnections {
+		p.slides = connectionOrder(p.cards, p.connections)
+	}
+	for i, card := range p.slides {
+		if card.ID == current {
+			p.index = i
+		}
+	}
+}
+
+// connectionOrder walks the cards depth-first along their connections.
+// Walks start from cards nothing points to, in reading order, so a chain
+// of connected cards reads from its beginning; anything left over (such
+// as cycles) follows in reading order.
+func connectionOrder(cards []Card, connections []Connection) []Card {
+	byID := make(map[string]Card, len(cards))
+	for _, card := range cards {
+		byID[card.ID] = card
+	}
+	next := map[string][]string{}
+	pointedTo := map[string]bool{}
+	for _, conn := range connections {
+		if _, ok := byID[conn.StartItemID]; !ok {
+			continue
+		}
+		if _, ok := byID[conn.EndItemID]; !ok {
+			continue
+		}
+		next[conn.StartItemID] = append(next[conn.StartItemID], conn.EndItemID)
+		pointedTo[conn.EndItemID] = true
+	}
+
+	// Follow a card's connections in reading order too.
+	rank := make(map[string]int, len(cards))
+	for i, card := range cards {
+		rank[card.ID] = i
+	}
+	for _, targets := range next {
+		sort.SliceStable(targets, func(i, j int) bool { return rank[targets[i]] < rank[targets[j]] })
+	}
+
+	ordered := make([]Card, 0, len(cards))
+	visited := map[string]bool{}
+	var walk func(id string)
+	walk = func(id string) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		ordered = append(ordered, byID[id])
+		for _, target := range next[id] {
+			walk(target)
+		}
+	}
+	for _, card := range cards {
+		if !pointedTo[card.ID] {
+			walk(card.ID)
+		}
+	}
+	for _, card := range cards {
+		walk(card.ID)
+	}
+	return ordered
+}
+
+// Update handles a key press and reports whether the presentation is over.
+func (p *presentation) Update(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, keys.Cancel, keys.Quit):
+		return true
+	case key.Matches(msg, keys.NextSlide):
+		if p.index < len(p.slides)-1 {
+			p.index++
+		}
+	case key.Matches(msg, keys.PrevSlide):
+		if p.index > 0 {
+			p.index--
+		}
+	case key.Matches(msg, keys.FirstSlide):
+		p.index = 0
+	case key.Matches(msg, keys.LastSlide):
+		p.index = len(p.slides) - 1
+	case key.Matches(msg, keys.SlideOrder):
+		p.toggleOrder()
+	}
+	return false
+}
+
+func (p *presentation) View(width, height int) string {
+	footerStyle := dimStyle()
+	order := "reading order"
+	if p.byConnections {
+		order = "following connections"
+	}
+	footer := footerStyle.Render(fmt.Sprintf("%d / %d · %s · ←/→ to move, o to change order, esc to stop", p.index+1, len(p.slides), order))
+
+	slideWidth := min(80, width-4)
+	body := renderMarkdown(p.slides[p.index].Name, slideWidth)
+
+	slide := lipgloss.Place(width, height-1, lipgloss.Center, lipgloss.Center, body)
+	return slide + "\n" + lipgloss.PlaceHorizontal(width, lipgloss.Center, footer)
+}
+
+// startPresentation presents the cards currently being listed.
+func (m *model) startPresentation() {
+	cards := m.visibleCards()
+	if len(cards) == 0 {
+		return
+	}
+	m.presentation = newPresentation(cards, m.selectedSpace.Connections)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prompt is a single-line text input shown in place of the help line, for
+// quick inline edits such as renaming something.
+type prompt struct {
+	label    string
+	input    textinput.Model
+	onSubmit func(value string) tea.Cmd
+}
+
+// openPrompt shows a prompt prefilled with value. onSubmit runs with the
+// entered text when the user presses enter; esc cancels.
+func (m *model) openPrompt(label, value string, onSubmit func(value string) tea.Cmd) tea.Cmd {
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetValue(value)
+	input.Width = m.width - len(label) - 4
+	input.Focus()
+	m.prompt = &prompt{label: label, input: input, onSubmit: onSubmit}
+	return textinput.Blink
+}
+
+func (m *model) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		p := m.prompt
+		m.prompt = nil
+		return p.onSubmit(p.input.Value())
+	case "esc":
+		m.prompt = nil
+		return nil
+	}
+	var cmd tea.Cmd
+	m.prompt.input, cmd = m.prompt.input.Update(msg)
+	return cmd
+}
+
+func (p *prompt) View() string {
+	return p.label + ": " + p.input.View()
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// syncInterval is how often queued writes are retried.
+const syncInterval = 30 * time.Second
+
+// queuedWrite is a card change that couldn't be sent because the API was
+// unreachable. It's saved to disk until it has been replayed.
+type queuedWrite struct {
+	Kind     string                   `json:"kind"` // create, inbox, update or remove
+	SpaceID  string                   `json:"spaceId,omitempty"`
+	Cards    []Card                   `json:"cards,omitempty"`
+	Fields   []map[string]interface{} `json:"fields,omitempty"`
+	CardID   string                   `json:"cardId,omitempty"`
+	QueuedAt time.Time                `json:"queuedAt"`
+	// MaybeSent is set when a create failed in a way that the API may
+	// have got it anyway, e.g. a timeout.
+	MaybeSent bool `json:"maybeSent,omitempty"`
+}
+
+func (w queuedWrite) send(ctx context.Context) error {
+	switch w.Kind {
+	case "create":
+		return api.CreateCards(ctx, w.SpaceID, w.Cards)
+	case "inbox":
+		for _, card := range w.Cards {
+			if err := api.CreateCardInInbox(ctx, card); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "update":
+		return api.UpdateCards(ctx, w.Fields)
+	case "remove":
+		return api.RemoveCard(ctx, w.CardID)
+	}
+	return fmt.Errorf("unknown queued write %q", w.Kind)
+}
+
+func (w queuedWrite) creates() bool {
+	return w.Kind == "create" || w.Kind == "inbox"
+}
+
+// unsent drops the cards of a create that may have been sent already and
+// that the API has, so replaying it doesn't make them twice. Cards have
+// their IDs from when they were made, so they can be looked up.
+func (w queuedWrite) unsent(ctx context.Context) (queuedWrite, error) {
+	if !w.MaybeSent {
+		return w, nil
+	}
+	var cards []Card
+	for _, card := range w.Cards {
+		_, err := api.Card(ctx, card.ID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, kinopio.ErrNotFound) {
+			return w, err
+		}
+		cards = append(cards, card)
+	}
+	w.Cards, w.MaybeSent = cards, false
+	return w, nil
+}
+
+// maybeSent notes that a write failed after perhaps reaching the API.
+// Only creates are marked, since the other writes are safe to repeat.
+func (w queuedWrite) maybeSent(err error) queuedWrite {
+	if w.creates() && !kinopio.NeverSent(err) {
+		w.MaybeSent = true
+	}
+	return w
+}
+
+// writeQueueMsg reports how many writes are waiting to be sent.
+type writeQueueMsg struct {
+	pending int
+}
+
+// queueSyncedMsg reports a replay of the queue. err is a write the API
+// rejected, which is dropped rather than retried forever.
+type queueSyncedMsg struct {
+	pending int
+	err     error
+}
+
+type syncTickMsg struct{}
+
+// writeQueue holds card writes made while offline, in order. Once anything
+// is queued, later writes queue behind it so they're replayed in the order
+// they were made.
+type writeQueue struct {
+	mu      sync.Mutex
+	pending []queuedWrite
+	path    string // Where the queue is saved, if not the config directory
+}
+
+var writes = &writeQueue{}
+
+// file returns where the queue is saved.
+func (q *writeQueue) file() (string, error) {
+	if q.path != "" {
+		return q.path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kinopio-tui", "queue.json"), nil
+}
+
+// load reads writes queued by an earlier run. A missing file is an empty
+// queue.
+func (q *writeQueue) load() error {
+	path, err := q.file()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading write queue: %v", err)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := json.Unmarshal(data, &q.pending); err != nil {
+		return fmt.Errorf("error parsing write queue %s: %v", path, err)
+	}
+	return nil
+}
+
+// save writes the queue to disk, removing the file once it's empty. The
+// caller must hold q.mu.
+func (q *writeQueue) save() error {
+	path, err := q.file()
+	if err != nil {
+		return err
+	}
+	if len(q.pending) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing write queue: %v", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating state directory: %v", err)
+	}
+	data, err := json.MarshalIndent(q.pending, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing write queue: %v", err)
+	}
+	return nil
+}
+
+func (q *writeQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// send makes a write, or queues it if there are writes ahead of it or the
+// API can't be reached. It returns a writeQueueMsg when the write was
+// queued.
+func (q *writeQueue) send(ctx context.Context, w queuedWrite) tea.Msg {
+	if q.len() > 0 {
+		return q.add(w)
+	}
+	if err := w.send(ctx); err != nil {
+		if tryLater(err) {
+			return q.add(w.maybeSent(err))
+		}
+		return err
+	}
+	return nil
+}
+
+func (q *writeQueue) add(w queuedWrite) tea.Msg {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	w.QueuedAt = time.Now()
+	q.pending = append(q.pending, w)
+	if err := q.save(); err != nil {
+		return err
+	}
+	return writeQueueMsg{pending: len(q.pending)}
+}
+
+// sync replays queued writes in order, stopping at the first one that
+// still can't reach the API.
+func (q *writeQueue) sync(ctx context.Context) queueSyncedMsg {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var rejected error
+	for len(q.pending) > 0 {
+		// If the cards can't be looked up for another reason, sending
+		// them again risks a duplicate but doesn't lose them.
+		w, err := q.pending[0].unsent(ctx)
+		if !tryLater(err) {
+			if w.creates() && len(w.Cards) == 0 {
+				q.pending = q.pending[1:]
+				continue
+			}
+			err = w.send(ctx)
+		}
+		if tryLater(err) {
+			q.pending[0] = w.maybeSent(err)
+			break
+		}
+		if err != nil {
+			rejected = err
+		}
+		q.pending = q.pending[1:]
+	}
+	if err := q.save(); err != nil && rejected == nil {
+		rejected = err
+	}
+	return queueSyncedMsg{pending: len(q.pending), err: rejected}
+}
+
+// tryLater reports whether a write failed only for now: the API couldn't
+// be reached, or is rate limiting.
+func tryLater(err error) bool {
+	return kinopio.IsUnreachable(err) || errors.Is(err, kinopio.ErrRateLimited)
+}
+
+// sendWrite is a command for a card write that's queued while offline.
+func sendWrite(w queuedWrite) tea.Cmd {
+	return func() tea.Msg { return writes.send(programCtx, w) }
+}
+
+func syncWrites() tea.Cmd {
+	return func() tea.Msg { return writes.sync(programCtx) }
+}
+
+func syncTick() tea.Cmd {
+	return tea.Tick(syncInterval, func(time.Time) tea.Msg { return syncTickMsg{} })
+}
+
+// writesQueued notes how many writes are waiting, and starts retrying
+// them if that isn't already scheduled.
+func (m *model) writesQueued(pending int) tea.Cmd {
+	m.pendingWrites = pending
+	if pending == 0 || m.syncScheduled {
+		return nil
+	}
+	m.offline = true
+	m.syncScheduled = true
+	return syncTick()
+}
+
+func (m *model) writesSynced(msg queueSyncedMsg) tea.Cmd {
+	m.syncScheduled = false
+	if msg.err != nil {
+		m.err = fmt.Errorf("a change made offline was rejected: %v", msg.err)
+	}
+	if msg.pending == 0 && m.pendingWrites > 0 {
+		m.offline = false
+		return tea.Batch(m.writesQueued(msg.pending), m.toast("Offline changes synced"))
+	}
+	return m.writesQueued(msg.pending)
+}
+
+// syncStatus describes queued writes and retries for the status bar, e.g.
+// "offline · 3 changes pending".
+func (m *model) syncStatus() string {
+	var parts []string
+	if m.pendingWrites > 0 {
+		parts = append(parts, "offline", plural(m.pendingWrites, "change")+" pending")
+	} else if m.offline {
+		parts = append(parts, "offline")
+	}
+	if status := m.retryStatus(); status != "" {
+		parts = append(parts, status)
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeCardAPI is enough of the API to replay queued card creates: the
+// cards it has, and the IDs of the cards each create sent.
+type fakeCardAPI struct {
+	mu      sync.Mutex
+	has     map[string]bool
+	created [][]string
+}
+
+func (f *fakeCardAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/card/"):
+		if !f.has[strings.TrimPrefix(r.URL.Path, "/card/")] {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "{}")
+	case r.Method == "POST" && r.URL.Path == "/card/multiple":
+		var body struct {
+			Cards []Card `json:"cards"`
+		}
+		json.NewDecoder(r.Body).Decode(&body) //nolint: errcheck
+		var ids []string
+		for _, card := range body.Cards {
+			f.has[card.ID] = true
+			ids = append(ids, card.ID)
+		}
+		f.created = append(f.created, ids)
+		fmt.Fprint(w, "{}")
+	default:
+		http.Error(w, "unexpected request", http.StatusTeapot)
+	}
+}
+
+// useFakeAPI points the API client at a fake server for the rest of the
+// test.
+func useFakeAPI(t *testing.T, handler http.Handler) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	saved := *api
+	t.Cleanup(func() { *api = saved })
+	api.BaseURL, api.APIKey, api.Limiter, api.Retries, api.Cache = server.URL, "test", nil, 0, nil
+}
+
+func TestSyncSkipsCardsAlreadyCreated(t *testing.T) {
+	tests := []struct {
+		name      string
+		has       []string
+		maybeSent bool
+		want      [][]string
+	}{
+		{name: "never sent", has: []string{"a"}, want: [][]string{{"a", "b"}}},
+		{name: "some got there", has: []string{"a"}, maybeSent: true, want: [][]string{{"b"}}},
+		{name: "all got there", has: []string{"a", "b"}, maybeSent: true},
+		{name: "none got there", maybeSent: true, want: [][]string{{"a", "b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCardAPI{has: map[string]bool{}}
+			for _, id := range tt.has {
+				fake.has[id] = true
+			}
+			useFakeAPI(t, fake)
+			q := &writeQueue{path: filepath.Join(t.TempDir(), "queue.json"), pending: []queuedWrite{{
+				Kind:      "create",
+				SpaceID:   "space",
+				Cards:     []Card{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}},
+				MaybeSent: tt.maybeSent,
+			}}}
+			msg := q.sync(context.Background())
+			if msg.err != nil || msg.pending != 0 {
+				t.Fatalf("sync() = %d pending, %v; want everything sent", msg.pending, msg.err)
+			}
+			if !reflect.DeepEqual(fake.created, tt.want) {
+				t.Errorf("created %v, want %v", fake.created, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaybeSent(t *testing.T) {
+	dial := fmt.Errorf("error performing request: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")})
+	lookup := fmt.Errorf("error performing request: %w", &net.DNSError{Err: "no such host", Name: "api.kinopio.club"})
+	read := fmt.Errorf("error performing request: %w", &net.OpError{Op: "read", Err: errors.New("i/o timeout")})
+	tests := []struct {
+		kind string
+		err  error
+		want bool
+	}{
+		{"create", dial, false},
+		{"create", lookup, false},
+		{"create", read, true},
+		{"inbox", read, true},
+		{"update", read, false},
+		{"remove", read, false},
+	}
+	for _, tt := range tests {
+		if got := (queuedWrite{Kind: tt.kind}).maybeSent(tt.err).MaybeSent; got != tt.want {
+			t.Errorf("%s failing with %v: MaybeSent = %v, want %v", tt.kind, tt.err, got, tt.want)
+		}
+	}
+}
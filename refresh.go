@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type refreshTickMsg struct{}
+
+// refresh re-fetches whatever is showing: the spaces list, or the open
+// space. The result is merged in place by spacesMsg and spaceRefreshed.
+func (m *model) refresh() tea.Cmd {
+	if m.currentView == "list" {
+		return tea.Batch(refreshSpaces(), fetchNotifications(false))
+	}
+	if m.inSpace() && m.selectedSpace.ID != "" {
+		return refreshSpace(m.selectedSpace.ID)
+	}
+	return nil
+}
+
+// refreshTick schedules the next poll when --refresh is set.
+func (m *model) refreshTick() tea.Cmd {
+	if m.refreshEvery <= 0 {
+		return nil
+	}
+	return tea.Tick(m.refreshEvery, func(time.Time) tea.Msg { return refreshTickMsg{} })
+}
+
+// keepCursor runs update, which rebuilds the list's items, then puts the
+// cursor back on the item it was on, wherever that item has moved to.
+func (m *model) keepCursor(update func()) {
+	selected := m.list.SelectedItem()
+	update()
+	if selected == nil {
+		return
+	}
+	key := itemKey(selected)
+	for i, item := range m.list.VisibleItems() {
+		if itemKey(item) == key {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// itemKey identifies a list item across refreshes.
+func itemKey(item list.Item) string {
+	switch item := item.(type) {
+	case listItem:
+		return "space:" + item.Space.ID
+	case cardListItem:
+		return "card:" + item.Card.ID
+	case boxListItem:
+		return "box:" + item.Box.ID
+	case graphCardItem:
+		return "card:" + item.Card.ID
+	case connectionListItem:
+		return "connection:" + item.Connection.ID
+	}
+	return item.FilterValue()
+}
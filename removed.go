@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type removedCardsMsg struct {
+	spaceID string
+	cards   []Card
+}
+
+type cardRestoredMsg struct {
+	Card Card
+}
+
+// removeSelectedCard removes the highlighted card after confirmation.
+// Kinopio keeps removed cards, so it can be restored from the space's
+// removed cards.
+func (m *model) removeSelectedCard() {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return
+	}
+	message := fmt.Sprintf("Remove %q?\nYou can restore it from Removed cards.", firstLine(card.Name))
+	m.askConfirm(message, true, func() tea.Cmd {
+		m.recordRemove("removal", card)
+		m.removeLocalCard(card.ID)
+		m.setCardItems()
+		return removeCard(card.ID)
+	})
+}
+
+func fetchRemovedCards(spaceID string) tea.Cmd {
+	return func() tea.Msg {
+		cards, err := api.RemovedCards(programCtx, spaceID)
+		if err != nil {
+			return err
+		}
+		return removedCardsMsg{spaceID: spaceID, cards: cards}
+	}
+}
+
+// openRemovedCards fetches the space's removed cards and lists them.
+func (m *model) openRemovedCards() tea.Cmd {
+	m.loading = true
+	return tea.Batch(fetchRemovedCards(m.selectedSpace.ID), m.spinner.Tick)
+}
+
+func (m *model) showRemovedCards() {
+	m.currentView = "removed"
+	m.list.Title = fmt.Sprintf("%s → Removed cards (%d)", m.selectedSpace.Name, len(m.removedCards))
+	items := make([]list.Item, len(m.removedCards))
+	for i, card := range m.removedCards {
+		items[i] = removedCardItem{card}
+	}
+	m.setItems(items)
+}
+
+// restoreCard brings back the highlighted removed card.
+func (m *model) restoreCard() tea.Cmd {
+	item, ok := m.list.SelectedItem().(removedCardItem)
+	if !ok {
+		return nil
+	}
+	card := item.Card
+	return func() tea.Msg {
+		if err := api.RestoreCard(programCtx, card.ID); err != nil {
+			return err
+		}
+		return cardRestoredMsg{Card: card}
+	}
+}
+
+// cardRestored moves a restored card from the removed list back into the
+// space.
+func (m *model) cardRestored(card Card) {
+	for i, removed := range m.removedCards {
+		if removed.ID == card.ID {
+			m.removedCards = append(m.removedCards[:i:i], m.removedCards[i+1:]...)
+			break
+		}
+	}
+	m.selectedSpace.Cards = append(m.selectedSpace.Cards, card)
+	if m.currentView == "removed" {
+		m.showRemovedCards()
+	}
+}
+
+type removedCardItem struct {
+	Card Card
+}
+
+func (i removedCardItem) FilterValue() string { return i.Card.Name }
+func (i removedCardItem) Title() string       { return firstLine(i.Card.Name) }
+func (i removedCardItem) Description() string {
+	return "removed " + relativeTime(i.Card.UpdatedAt)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
+)
+
+// programCtx is cancelled when the TUI exits, abandoning the requests
+// still in flight. Commands make their requests with it.
+var programCtx = context.Background()
+
+// retryingMsg reports that a request failed and is about to be retried.
+type retryingMsg struct {
+	retry kinopio.Retry
+}
+
+type retryTickMsg struct{}
+
+// retrying notes a request's retry so the status bar can count down to
+// it.
+func (m *model) retrying(r kinopio.Retry) tea.Cmd {
+	m.retryAt = time.Now().Add(r.Wait)
+	m.rateLimited = r.RateLimited
+	if m.retryTicking {
+		return nil
+	}
+	m.retryTicking = true
+	return retryTick()
+}
+
+// retryTicked updates the countdown, clearing it once the retry is due.
+func (m *model) retryTicked() tea.Cmd {
+	if time.Now().Before(m.retryAt) {
+		return retryTick()
+	}
+	m.retryTicking = false
+	m.retryAt = time.Time{}
+	return nil
+}
+
+// retryStatus describes an upcoming retry, e.g. "retrying in 2s" or
+// "rate limited, retrying in 30s".
+func (m *model) retryStatus() string {
+	if m.retryAt.IsZero() {
+		return ""
+	}
+	status := "retrying…"
+	if wait := time.Until(m.retryAt).Round(time.Second); wait > 0 {
+		status = fmt.Sprintf("retrying in %s", wait)
+	}
+	if m.rateLimited {
+		status = "rate limited, " + status
+	}
+	return status
+}
+
+func retryTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return retryTickMsg{} })
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const searchMaxResults = 12
+
+// cardSearch is an overlay for finding cards in every space. It searches
+// the spaces that have been fetched, from this run or the offline cache,
+// while the rest are fetched in the background.
+type cardSearch struct {
+	input   textinput.Model
+	spaces  []Space
+	total   int // How many spaces there are, fetched or not
+	results []searchResult
+	cursor  int
+}
+
+type searchResult struct {
+	space   Space
+	card    Card
+	matched []int // Indexes of the matched runes in the card's first line
+}
+
+// openSearch opens the search overlay, fetching any spaces that aren't
+// cached yet.
+func (m *model) openSearch() tea.Cmd {
+	input := textinput.New()
+	input.Placeholder = "Search cards in all spaces…"
+	input.Focus()
+	m.search = &cardSearch{input: input, total: len(m.spaces)}
+	spaces, missing := m.searchableSpaces()
+	m.search.spaces = spaces
+	if len(missing) == 0 {
+		return textinput.Blink
+	}
+	return tea.Batch(textinput.Blink, prefetch(missing))
+}
+
+// searchableSpaces returns the spaces whose cards are at hand, and the
+// IDs of the ones that aren't.
+func (m *model) searchableSpaces() ([]Space, []string) {
+	var spaces []Space
+	var missing []string
+	for _, listed := range m.spaces {
+		if listed.ID == m.selectedSpace.ID {
+			spaces = append(spaces, m.selectedSpace)
+		} else if space, ok := m.spaceCache[listed.ID]; ok {
+			spaces = append(spaces, space)
+		} else if space, ok := loadCachedSpace(listed.ID); ok {
+			m.spaceCache[listed.ID] = space
+			spaces = append(spaces, space)
+		} else {
+			missing = append(missing, listed.ID)
+		}
+	}
+	return spaces, missing
+}
+
+// reindexSearch picks up spaces fetched since the search opened.
+func (m *model) reindexSearch() {
+	m.search.spaces, _ = m.searchableSpaces()
+	m.search.filter()
+}
+
+// filter finds the cards whose text contains every word of the query.
+func (s *cardSearch) filter() {
+	s.results = nil
+	s.cursor = 0
+	words := strings.Fields(strings.ToLower(s.input.Value()))
+	if len(words) == 0 {
+		return
+	}
+	for _, space := range s.spaces {
+		for _, card := range space.Cards {
+			if matched, ok := matchWords(firstLine(card.Name), card.Name, words); ok {
+				s.results = append(s.results, searchResult{space: space, card: card, matched: matched})
+				if len(s.results) == searchMaxResults {
+					return
+				}
+			}
+		}
+	}
+}
+
+// matchWords reports whether text contains all of words, which must be
+// lowercase, and where they are in line, for highlighting.
+func matchWords(line, text string, words []string) ([]int, bool) {
+	lower := strings.ToLower(text)
+	for _, word := range words {
+		if !strings.Contains(lower, word) {
+			return nil, false
+		}
+	}
+	var matched []int
+	runes := []rune(strings.ToLower(line))
+	for _, word := range words {
+		w := []rune(word)
+		for i := 0; i+len(w) <= len(runes); i++ {
+			if string(runes[i:i+len(w)]) == word {
+				for j := range w {
+					matched = append(matched, i+j)
+				}
+				break
+			}
+		}
+	}
+	return matched, true
+}
+
+// Update handles a key press. It reports whether the search is finished
+// and, if a card was picked, which one.
+func (s *cardSearch) Update(msg tea.KeyMsg) (bool, *searchResult, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return true, nil, nil
+	case "enter":
+		if len(s.results) == 0 {
+			return false, nil, nil
+		}
+		return true, &s.results[s.cursor], nil
+	case "up", "ctrl+p":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+		return false, nil, nil
+	case "down", "ctrl+n":
+		if s.cursor < len(s.results)-1 {
+			s.cursor++
+		}
+		return false, nil, nil
+	}
+	var cmd tea.Cmd
+	query := s.input.Value()
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != query {
+		s.filter()
+	}
+	return false, nil, cmd
+}
+
+func (s *cardSearch) View(width int) string {
+	width = min(80, width-4)
+	normal := lipgloss.NewStyle().Padding(0, 1)
+	selected := normal.Foreground(theme.OnAccent).Background(theme.Accent)
+	match := lipgloss.NewStyle().Underline(true).Bold(true)
+	dim := dimStyle()
+
+	lines := []string{s.input.View(), ""}
+	for i, r := range s.results {
+		style := normal
+		if i == s.cursor {
+			style = selected
+		}
+		name := lipgloss.StyleRunes(firstLine(r.card.Name), r.matched, match.Inherit(style.Inline(true)), style.Inline(true))
+		name += style.Inline(true).Render(" · ") + dim.Inherit(style.Inline(true)).Render(r.space.Name)
+		lines = append(lines, style.Width(width-4).MaxWidth(width-4).Render(name))
+	}
+	if s.input.Value() != "" && len(s.results) == 0 {
+		lines = append(lines, dim.Render("No matching cards"))
+	}
+	if len(s.spaces) < s.total {
+		lines = append(lines, "", dim.Render(fmt.Sprintf("%d of %s searched so far…", len(s.spaces), plural(s.total, "space"))))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// updateSearch forwards a key press to the search and opens the chosen
+// card in its space.
+func (m *model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	done, result, cmd := m.search.Update(msg)
+	if !done {
+		return cmd
+	}
+	m.search = nil
+	if result == nil {
+		return nil
+	}
+	m.pendingLocation = &location{view: "cardDetails", spaceID: result.space.ID, cardID: result.card.ID}
+	return m.openSpace(result.space)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// sectionItem is a header that splits a list into groups. It never matches
+// a filter, so filtering shows a flat list of results.
+type sectionItem struct {
+	title string
+}
+
+func (i sectionItem) FilterValue() string { return "" }
+func (i sectionItem) Title() string       { return i.title }
+func (i sectionItem) Description() string { return "" }
+
+// maxPrefixLength limits how much of a name before a colon counts as a
+// "Project:"-style prefix.
+const maxPrefixLength = 24
+
+// spacePrefix returns the group a space name belongs to: its leading
+// emoji, or the text before a "Prefix:" colon. Names without either
+// return "".
+func spacePrefix(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r)
+	}); i != 0 {
+		if i < 0 {
+			return name
+		}
+		return name[:i]
+	}
+	if before, _, ok := strings.Cut(name, ":"); ok && len(before) <= maxPrefixLength && strings.TrimSpace(before) != "" {
+		return strings.TrimSpace(before)
+	}
+	return ""
+}
+
+// groupedSpaceItems sections spaces by prefix. Prefixes used by only one
+// space aren't much of a group, so those spaces go under "Other" with the
+// unprefixed ones.
+func (m *model) groupedSpaceItems(spaces []Space) []list.Item {
+	groups := map[string][]Space{}
+	for _, space := range spaces {
+		prefix := spacePrefix(space.Name)
+		groups[prefix] = append(groups[prefix], space)
+	}
+	for prefix, spaces := range groups {
+		if prefix != "" && len(spaces) < 2 {
+			groups[""] = append(groups[""], spaces...)
+			delete(groups, prefix)
+		}
+	}
+
+	var prefixes []string
+	for prefix := range groups {
+		if prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Strings(prefixes)
+	if len(groups[""]) > 0 {
+		prefixes = append(prefixes, "")
+	}
+
+	var items []list.Item
+	for _, prefix := range prefixes {
+		title := prefix
+		if title == "" {
+			title = "Other"
+		}
+		items = append(items, sectionItem{title})
+		for _, space := range groups[prefix] {
+			items = append(items, m.spaceItem(space))
+		}
+	}
+	return items
+}
+
+// groupedCardItems sections cards by the box they sit in, with boxes in
+// reading order and cards outside any box last under "Unboxed". Cards
+// keep their order within each section, and are numbered straight
+// through.
+func (m *model) groupedCardItems(cards []Card) []list.Item {
+	groups := map[string][]Card{}
+	for _, card := range cards {
+		box, _ := m.boxForCard(card)
+		groups[box.ID] = append(groups[box.ID], card)
+	}
+
+	boxes := slices.Clone(m.selectedSpace.Boxes)
+	sort.SliceStable(boxes, func(i, j int) bool {
+		if boxes[i].Y != boxes[j].Y {
+			return boxes[i].Y < boxes[j].Y
+		}
+		return boxes[i].X < boxes[j].X
+	})
+	boxes = append(boxes, Box{Name: "Unboxed"})
+
+	width := len(fmt.Sprint(len(cards)))
+	var items []list.Item
+	number := 0
+	for _, box := range boxes {
+		if len(groups[box.ID]) == 0 {
+			continue
+		}
+		items = append(items, sectionItem{fmt.Sprintf("%s (%d)", box.Name, len(groups[box.ID]))})
+		for _, card := range groups[box.ID] {
+			number++
+			items = append(items, m.cardItem(card, number, width))
+		}
+	}
+	return items
+}
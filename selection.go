@@ -0,0 +1,58 @@
+package main
+
+// selectMatching adds every card in the current filter results, or the
+// find matches while finding, to the selection. If they are all selected
+// already, it deselects them instead.
+func (m *model) selectMatching() {
+	var ids []string
+	if m.find != nil {
+		items := m.list.Items()
+		for _, i := range m.findMatches() {
+			ids = append(ids, items[i].(cardListItem).Card.ID)
+		}
+	} else {
+		for _, item := range m.list.VisibleItems() {
+			if item, ok := item.(cardListItem); ok {
+				ids = append(ids, item.Card.ID)
+			}
+		}
+	}
+
+	allSelected := true
+	for _, id := range ids {
+		allSelected = allSelected && m.selected[id]
+	}
+	for _, id := range ids {
+		if allSelected {
+			delete(m.selected, id)
+		} else {
+			m.selected[id] = true
+		}
+	}
+	m.refreshCardItems()
+}
+
+// selectedCards returns the selected cards in space order.
+func (m *model) selectedCards() []Card {
+	var cards []Card
+	for _, card := range m.selectedSpace.Cards {
+		if m.selected[card.ID] {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// refreshCardItems re-renders the visible card items in place, keeping
+// the current filter, for changes like selection that don't affect which
+// cards are listed.
+func (m *model) refreshCardItems() {
+	for i, item := range m.list.Items() {
+		if item, ok := item.(cardListItem); ok {
+			item.selected = m.selected[item.Card.ID]
+			item.changed = m.recentlyChanged(item.Card.ID)
+			m.setItem(i, item)
+		}
+	}
+	m.setCardTitle()
+}
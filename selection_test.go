@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestSelectMatching(t *testing.T) {
+	items := []list.Item{
+		cardListItem{Card: Card{ID: "a", Name: "apple"}, matched: []int{0}},
+		cardListItem{Card: Card{ID: "b", Name: "banana"}},
+		cardListItem{Card: Card{ID: "c", Name: "avocado"}, matched: []int{0}},
+	}
+	tests := []struct {
+		name     string
+		finding  bool
+		selected []string
+		want     []string
+	}{
+		{name: "everything listed", want: []string{"a", "b", "c"}},
+		{name: "find matches", finding: true, want: []string{"a", "c"}},
+		{name: "find matches on top of the selection", finding: true, selected: []string{"b", "c"}, want: []string{"a", "b", "c"}},
+		{name: "all selected already", finding: true, selected: []string{"a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &model{list: list.New(items, list.NewDefaultDelegate(), 80, 20), selected: map[string]bool{}}
+			if tt.finding {
+				m.find = &cardFind{}
+			}
+			for _, id := range tt.selected {
+				m.selected[id] = true
+			}
+			m.selectMatching()
+			var got []string
+			for id := range m.selected {
+				got = append(got, id)
+			}
+			slices.Sort(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selected %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
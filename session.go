@@ -0,0 +1,78 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recentListed is how many recently visited spaces the spaces list shows
+// in its Recent section.
+const recentListed = 5
+
+// recentSpaces returns the spaces visited most recently, newest first, as
+// long as they're still in the spaces list.
+func (m *model) recentSpaces() []Space {
+	var recent []Space
+	for _, id := range m.state.RecentSpaces {
+		i := slices.IndexFunc(m.spaces, func(s Space) bool { return s.ID == id })
+		if i < 0 || isInbox(m.spaces[i]) {
+			continue
+		}
+		recent = append(recent, m.spaces[i])
+		if len(recent) == recentListed {
+			break
+		}
+	}
+	return recent
+}
+
+// recentSpaceItems is the Recent section of the spaces list, if any
+// spaces have been visited.
+func (m *model) recentSpaceItems() []list.Item {
+	recent := m.recentSpaces()
+	if len(recent) == 0 {
+		return nil
+	}
+	items := []list.Item{sectionItem{"Recent"}}
+	for _, space := range recent {
+		items = append(items, m.spaceItem(space))
+	}
+	return items
+}
+
+// saveSession remembers the space and card the user was on, for --resume
+// to reopen next time. Nothing is remembered outside a space.
+func (m *model) saveSession() error {
+	if !m.inSpace() || m.selectedSpace.ID == "" {
+		return nil
+	}
+	m.state.LastSpace = m.selectedSpace.ID
+	m.state.LastCard = ""
+	m.state.LastCardOpen = m.currentView == "cardDetails"
+	if m.currentView == "cards" || m.currentView == "cardDetails" {
+		if card, ok := m.selectedListCard(); ok {
+			m.state.LastCard = card.ID
+		}
+	}
+	return m.state.save()
+}
+
+// resumeSession opens the space and card saved by saveSession once the
+// spaces have loaded. It reports false when there's nothing to resume,
+// or the space is gone.
+func (m *model) resumeSession() (tea.Cmd, bool) {
+	spaceID := m.state.LastSpace
+	m.resume = false
+	i := slices.IndexFunc(m.spaces, func(s Space) bool { return s.ID == spaceID })
+	if spaceID == "" || i < 0 {
+		return nil, false
+	}
+	loc := location{view: "cards", spaceID: spaceID, cardID: m.state.LastCard}
+	if m.state.LastCardOpen && m.state.LastCard != "" {
+		loc.view = "cardDetails"
+	}
+	m.pendingLocation = &loc
+	return m.openSpace(m.spaces[i]), true
+}
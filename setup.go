@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bentsai/kinopio-tui/internal/kinopio"
+)
+
+// setupModel asks for an API key on first run, either pasted in or
+// fetched by signing in, checks it works, and saves it to the config file.
+// The TUI also shows it when the API stops accepting the key.
+type setupModel struct {
+	reauth   bool // Replacing a rejected key, inside the TUI
+	signIn   bool // Sign in with email and password instead of pasting a key
+	key      textinput.Model
+	email    textinput.Model
+	password textinput.Model
+	spinner  spinner.Model
+	checking bool
+	user     User
+	apiKey   string
+	err      error
+}
+
+type setupDoneMsg struct {
+	apiKey string
+	user   User
+}
+
+type setupFailedMsg struct {
+	err error
+}
+
+func newSetupModel() *setupModel {
+	key := textinput.New()
+	key.Prompt = "API key: "
+	key.EchoMode = textinput.EchoPassword
+	key.Focus()
+
+	email := textinput.New()
+	email.Prompt = "Email:    "
+
+	password := textinput.New()
+	password.Prompt = "Password: "
+	password.EchoMode = textinput.EchoPassword
+
+	return &setupModel{
+		key:      key,
+		email:    email,
+		password: password,
+		spinner:  spinner.New(spinner.WithSpinner(spinner.Dot)),
+	}
+}
+
+func (m *setupModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// inputs returns the fields shown in the current mode.
+func (m *setupModel) inputs() []*textinput.Model {
+	if m.signIn {
+		return []*textinput.Model{&m.email, &m.password}
+	}
+	return []*textinput.Model{&m.key}
+}
+
+func (m *setupModel) focused() int {
+	for i, input := range m.inputs() {
+		if input.Focused() {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *setupModel) focus(i int) tea.Cmd {
+	inputs := m.inputs()
+	for _, input := range inputs {
+		input.Blur()
+	}
+	return inputs[(i+len(inputs))%len(inputs)].Focus()
+}
+
+func (m *setupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case setupDoneMsg:
+		m.apiKey, m.user = msg.apiKey, msg.user
+		if m.reauth {
+			return m, nil
+		}
+		return m, tea.Quit
+	case setupFailedMsg:
+		m.checking = false
+		m.err = msg.err
+		return m, nil
+	case tea.KeyMsg:
+		if m.checking && msg.String() != "ctrl+c" {
+			return m, nil
+		}
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		case "ctrl+t":
+			m.key.Blur()
+			m.email.Blur()
+			m.password.Blur()
+			m.signIn = !m.signIn
+			m.err = nil
+			return m, m.focus(0)
+		case "tab", "down":
+			return m, m.focus(m.focused() + 1)
+		case "shift+tab", "up":
+			return m, m.focus(m.focused() - 1)
+		case "enter":
+			if m.focused() < len(m.inputs())-1 {
+				return m, m.focus(m.focused() + 1)
+			}
+			m.checking = true
+			m.err = nil
+			return m, tea.Batch(m.spinner.Tick, m.check())
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.checking {
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	for _, input := range m.inputs() {
+		if input.Focused() {
+			*input, cmd = input.Update(msg)
+		}
+	}
+	return m, cmd
+}
+
+// check gets the API key, signing in first if need be, and makes sure it
+// works by fetching the user it belongs to.
+func (m *setupModel) check() tea.Cmd {
+	signIn := m.signIn
+	key := strings.TrimSpace(m.key.Value())
+	email, password := strings.TrimSpace(m.email.Value()), m.password.Value()
+	return func() tea.Msg {
+		ctx := context.Background()
+		client := *api
+		if signIn {
+			var err error
+			if key, err = client.SignIn(ctx, email, password); err != nil {
+				return setupFailedMsg{err}
+			}
+		}
+		if key == "" {
+			return setupFailedMsg{errors.New("enter your API key")}
+		}
+		client.APIKey = key
+		user, err := client.CurrentUser(ctx)
+		// Nothing about the user is off limits to their own key.
+		if errors.Is(err, kinopio.ErrUnauthorized) || errors.Is(err, kinopio.ErrForbidden) {
+			return setupFailedMsg{errors.New("that API key wasn't accepted")}
+		}
+		if err != nil {
+			return setupFailedMsg{err}
+		}
+		return setupDoneMsg{apiKey: key, user: user}
+	}
+}
+
+func (m *setupModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Render("Welcome to Kinopio TUI")
+	if m.reauth {
+		title = lipgloss.NewStyle().Bold(true).Render("Kinopio didn't accept your API key")
+	}
+	dim := dimStyle()
+
+	lines := []string{title, ""}
+	if m.signIn {
+		lines = append(lines, "Sign in to your Kinopio account to fetch your API key.", "", m.email.View(), m.password.View())
+	} else {
+		intro := "Paste your API key from https://help.kinopio.club/api/ to get started."
+		if m.reauth {
+			intro = "Paste a new API key from https://help.kinopio.club/api/ to carry on."
+		}
+		lines = append(lines, intro, "", m.key.View())
+	}
+	lines = append(lines, "")
+	switch {
+	case m.checking:
+		lines = append(lines, m.spinner.View()+" Checking…")
+	case m.err != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.Danger).Render(m.err.Error()))
+	}
+	other := "ctrl+t to sign in with email instead"
+	if m.signIn {
+		other = "ctrl+t to paste an API key instead"
+	}
+	quit := "esc to quit"
+	if m.reauth {
+		quit = "esc to cancel"
+	}
+	lines = append(lines, "", dim.Render("enter to continue · "+other+" · "+quit))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// runSetup asks for an API key and saves it. It reports false if the user
+// quit without finishing.
+func runSetup() (bool, error) {
+	m := newSetupModel()
+	if _, err := tea.NewProgram(m).Run(); err != nil {
+		return false, fmt.Errorf("error running setup: %v", err)
+	}
+	if m.apiKey == "" {
+		return false, nil
+	}
+	api.APIKey = m.apiKey
+	where, err := saveAPIKey(m.apiKey)
+	if err != nil {
+		return false, err
+	}
+	if m.user.Name != "" {
+		fmt.Printf("Signed in as %s. ", m.user.Name)
+	}
+	fmt.Printf("Your API key is saved in %s.\n", where)
+	return true, nil
+}
+
+// saveAPIKey stores the API key where the auth setting says to, and
+// reports where that is. Keys kept in the keychain are noted in the config
+// file, so later runs know to look there.
+func saveAPIKey(key string) (string, error) {
+	if userConfig.Auth != authKeyring {
+		return setConfigValue("api_key", key)
+	}
+	if err := saveKeyringAPIKey(key); err != nil {
+		return "", err
+	}
+	if _, err := setConfigValue("auth", authKeyring); err != nil {
+		return "", err
+	}
+	return "the system keychain", nil
+}
+
+// setConfigValue writes a top-level setting into the config file, keeping
+// anything else already there, and returns the file's path.
+func setConfigValue(name, value string) (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("error reading config file: %v", err)
+	}
+	line := fmt.Sprintf("%s = %q", name, value)
+	content := string(data)
+	if pattern := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(name) + `\s*=.*$`); pattern.MatchString(content) {
+		content = pattern.ReplaceAllLiteralString(content, line)
+	} else {
+		// Top-level keys must come before any [table].
+		content = line + "\n" + content
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("error creating config directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return "", fmt.Errorf("error writing config file: %v", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// privacyLevels are Kinopio's space privacy settings, in the order enter
+// cycles through them.
+var privacyLevels = []string{"private", "closed", "open"}
+
+// privacyDescription says who can see a space with a privacy setting.
+func privacyDescription(privacy string) string {
+	switch privacy {
+	case "private":
+		return "Private: only you and collaborators can see it"
+	case "closed":
+		return "Closed: anyone with the link can read it"
+	case "open":
+		return "Open: anyone with the link can read and edit it"
+	}
+	return orNone(privacy)
+}
+
+// collaboratorNames lists the people a space is shared with: its owners,
+// then its collaborators.
+func collaboratorNames(space Space) string {
+	var names []string
+	for _, user := range slices.Concat(space.Users, space.Collaborators) {
+		if user.Name != "" && !slices.Contains(names, user.Name) {
+			names = append(names, user.Name)
+		}
+	}
+	if len(names) == 0 {
+		return "Just you"
+	}
+	return strings.Join(names, ", ")
+}
+
+// inviteURL is the link that makes whoever opens it a collaborator on a
+// space.
+func inviteURL(space Space) string {
+	query := url.Values{
+		"spaceId":         {space.ID},
+		"collaboratorKey": {space.CollaboratorKey},
+		"name":            {space.Name},
+	}
+	return "https://kinopio.club/invite?" + query.Encode()
+}
+
+// cyclePrivacy moves the open space on to the next privacy setting.
+func (m *model) cyclePrivacy() tea.Cmd {
+	i := slices.Index(privacyLevels, m.selectedSpace.Privacy)
+	privacy := privacyLevels[(i+1)%len(privacyLevels)]
+	id := m.selectedSpace.ID
+	m.selectedSpace.Privacy = privacy
+	m.updateLocalSpace(id, func(space *Space) { space.Privacy = privacy })
+	m.keepCursor(m.showDetails)
+	return tea.Batch(apiCmd(func(ctx context.Context) error {
+		return api.UpdateSpace(ctx, map[string]interface{}{"id": id, "privacy": privacy})
+	}), m.toast("The space is now "+privacy))
+}
+
+// copyInviteURL copies the open space's invite link, first making the
+// space a collaborator key if it has none.
+func (m *model) copyInviteURL() tea.Cmd {
+	var save tea.Cmd
+	if m.selectedSpace.CollaboratorKey == "" {
+		id, collaboratorKey := m.selectedSpace.ID, newID()
+		m.selectedSpace.CollaboratorKey = collaboratorKey
+		m.updateLocalSpace(id, func(space *Space) { space.CollaboratorKey = collaboratorKey })
+		save = apiCmd(func(ctx context.Context) error {
+			return api.UpdateSpace(ctx, map[string]interface{}{"id": id, "collaboratorKey": collaboratorKey})
+		})
+	}
+	return tea.Batch(save, m.copyToClipboard("the invite link", inviteURL(m.selectedSpace)))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// cardSort is the order the cards list is shown in. s cycles through them
+// in this order, starting from reading order.
+type cardSort int
+
+const (
+	sortReading cardSort = iota // Top-to-bottom, left-to-right
+	sortName                    // Alphabetical
+	sortCreated                 // Newest first
+	sortUpdated                 // Most recently updated first
+	sortAuthor                  // Grouped by who made them
+	sortAPI                     // The order the API returned
+)
+
+func (s cardSort) String() string {
+	switch s {
+	case sortReading:
+		return "reading order"
+	case sortName:
+		return "by name"
+	case sortCreated:
+		return "newest first"
+	case sortUpdated:
+		return "recently updated first"
+	case sortAuthor:
+		return "by author"
+	default:
+		return "API order"
+	}
+}
+
+func (s cardSort) next() cardSort {
+	if s == sortAPI {
+		return sortReading
+	}
+	return s + 1
+}
+
+// readingRowTolerance is how far apart (in canvas pixels) two cards' y
+// coordinates can be while still being read as the same row.
+const readingRowTolerance = 40
+
+// sortCards returns a sorted copy of cards.
+func sortCards(cards []Card, by cardSort) []Card {
+	sorted := make([]Card, len(cards))
+	copy(sorted, cards)
+	switch by {
+	case sortReading:
+		sortReadingOrder(sorted)
+	case sortName:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+		})
+	case sortCreated:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.After(sorted[j].CreatedAt) })
+	case sortUpdated:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].UpdatedAt.After(sorted[j].UpdatedAt) })
+	case sortAuthor:
+		sortReadingOrder(sorted)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].UserID < sorted[j].UserID })
+	}
+	return sorted
+}
+
+// sortReadingOrder orders cards the way the canvas reads: cards are
+// bucketed into rows by y, then each row is read left to right.
+func sortReadingOrder(cards []Card) {
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Y < cards[j].Y })
+
+	rowStart := 0
+	for i := 1; i <= len(cards); i++ {
+		if i < len(cards) && cards[i].Y-cards[rowStart].Y <= readingRowTolerance {
+			continue
+		}
+		row := cards[rowStart:i]
+		sort.SliceStable(row, func(a, b int) bool { return row[a].X < row[b].X })
+		rowStart = i
+	}
+}
+
+// sortDetail is the field a card is sorted by, for its description in the
+// cards list, unless that's shown already.
+func (m *model) sortDetail(card Card) string {
+	switch m.cardSort {
+	case sortCreated:
+		return "created " + relativeTime(card.CreatedAt)
+	case sortUpdated:
+		if !m.showUpdated {
+			return "updated " + relativeTime(card.UpdatedAt)
+		}
+	case sortAuthor:
+		return "by " + orNone(m.userName(card.UserID))
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// blankSpace is the first choice when starting a new space from a
+// template.
+const blankSpace = "Blank space"
+
+// duplicateSpace prompts for a name, then copies a space's cards, boxes
+// and connections into a new space of the user's own and opens it. The
+// space is fetched afresh, so it needn't be loaded.
+func (m *model) duplicateSpace(source Space) tea.Cmd {
+	return m.openPrompt("Duplicate as", source.Name, func(name string) tea.Cmd {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil
+		}
+		m.loading = true
+		return tea.Batch(func() tea.Msg {
+			contents, err := api.Space(programCtx, source.ID)
+			if err != nil {
+				return err
+			}
+			space, err := api.CreateSpace(programCtx, newID(), name)
+			if err != nil {
+				return err
+			}
+			if err := restoreItems(programCtx, space.ID, contents); err != nil {
+				return err
+			}
+			if full, err := api.Space(programCtx, space.ID); err == nil {
+				space = full
+			}
+			return spaceCreatedMsg{Space: space}
+		}, m.spinner.Tick)
+	})
+}
+
+// templateSpaces returns the user's spaces marked as templates.
+func (m *model) templateSpaces() []Space {
+	var templates []Space
+	for _, space := range m.listedSpaces() {
+		if space.IsTemplate {
+			templates = append(templates, space)
+		}
+	}
+	return templates
+}
+
+// startNewSpace prompts for a new space's name, first offering the user's
+// templates to start from if there are any.
+func (m *model) startNewSpace() tea.Cmd {
+	if len(m.templateSpaces()) == 0 {
+		return m.newSpace()
+	}
+	m.showTemplates()
+	return nil
+}
+
+// showTemplates lists what a new space can start from: nothing, or a copy
+// of one of the user's templates.
+func (m *model) showTemplates() {
+	m.currentView = "templates"
+	m.list.Title = "New space"
+	items := []list.Item{detailListItem{blankSpace, "Start from an empty space"}}
+	for _, space := range m.templateSpaces() {
+		items = append(items, m.spaceItem(space))
+	}
+	m.setItems(items)
+}
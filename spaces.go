@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newSpace prompts for a name and creates a space with it, then opens the
+// space. A blank name gets a random one, like N does.
+func (m *model) newSpace() tea.Cmd {
+	return m.openPrompt("New space", "", func(name string) tea.Cmd {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = randomName()
+		}
+		return m.startCreateSpace(name)
+	})
+}
+
+func (m *model) startCreateSpace(name string) tea.Cmd {
+	m.loading = true
+	return tea.Batch(createSpace(name), m.spinner.Tick)
+}
+
+func (m *model) showSpaceActions() {
+	m.currentView = "spaceActions"
+	m.list.Title = m.selectedSpace.Name + " → Actions"
+	m.setItems([]list.Item{
+		detailListItem{"Rename", "Change the space's name"},
+		detailListItem{"Export", "Save the space as Markdown"},
+		detailListItem{"Duplicate", "Copy the space's cards, boxes and connections into a new space"},
+		detailListItem{"Delete", "Move the space to your removed spaces"},
+	})
+}
+
+// renameSpace prompts for a new name for the selected space.
+func (m *model) renameSpace() tea.Cmd {
+	return m.openPrompt("Rename space", m.selectedSpace.Name, func(name string) tea.Cmd {
+		name = strings.TrimSpace(name)
+		if name == "" || name == m.selectedSpace.Name {
+			return nil
+		}
+		id := m.selectedSpace.ID
+		m.selectedSpace.Name = name
+		m.updateLocalSpace(id, func(space *Space) { space.Name = name })
+		m.showSpaceActions()
+		return apiCmd(func(ctx context.Context) error {
+			return api.UpdateSpace(ctx, map[string]interface{}{"id": id, "name": name})
+		})
+	})
+}
+
+// deleteSpace removes the selected space after confirmation. Kinopio keeps
+// removed spaces, so it can be restored from the web app.
+func (m *model) deleteSpace() {
+	space := m.selectedSpace
+	message := fmt.Sprintf("Delete %q?\nYou can restore it from removed spaces in Kinopio.", space.Name)
+	m.askConfirm(message, true, func() tea.Cmd {
+		m.spaces = slices.DeleteFunc(m.spaces, func(s Space) bool { return s.ID == space.ID })
+		delete(m.spaceCache, space.ID)
+		delete(m.state.Pins, space.ID)
+		m.state.WarmSpaces = slices.DeleteFunc(m.state.WarmSpaces, func(id string) bool { return id == space.ID })
+		m.state.RecentSpaces = slices.DeleteFunc(m.state.RecentSpaces, func(id string) bool { return id == space.ID })
+		m.selectedSpace = Space{}
+		m.showSpaces()
+
+		cmd := apiCmd(func(ctx context.Context) error { return api.RemoveSpace(ctx, space.ID) })
+		if err := m.state.save(); err != nil {
+			return tea.Batch(cmd, func() tea.Msg { return err })
+		}
+		return cmd
+	})
+}
+
+// updateLocalSpace applies a change to the local copies of a space in the
+// spaces list and the space cache.
+func (m *model) updateLocalSpace(spaceID string, change func(*Space)) {
+	for i := range m.spaces {
+		if m.spaces[i].ID == spaceID {
+			change(&m.spaces[i])
+		}
+	}
+	if space, ok := m.spaceCache[spaceID]; ok {
+		change(&space)
+		m.spaceCache[spaceID] = space
+	}
+}
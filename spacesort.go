@@ -0,0 +1,156 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// spaceSort is the order the spaces list is shown in. s cycles through
+// them in this order.
+type spaceSort int
+
+const (
+	spaceSortAPI     spaceSort = iota // The order the API returned
+	spaceSortUpdated                  // Most recently updated first
+	spaceSortVisited                  // Most recently opened here first
+	spaceSortName                     // Alphabetical
+	spaceSortCards                    // Most cards first
+)
+
+func (s spaceSort) String() string {
+	switch s {
+	case spaceSortUpdated:
+		return "recently updated first"
+	case spaceSortVisited:
+		return "recently visited first"
+	case spaceSortName:
+		return "by name"
+	case spaceSortCards:
+		return "most cards first"
+	default:
+		return "API order"
+	}
+}
+
+func (s spaceSort) next() spaceSort {
+	if s == spaceSortCards {
+		return spaceSortAPI
+	}
+	return s + 1
+}
+
+// spaceGrouping is how the spaces list is sectioned. g cycles through
+// them in this order.
+type spaceGrouping int
+
+const (
+	groupNone   spaceGrouping = iota
+	groupPrefix               // By name prefix, see spacePrefix
+	groupKind                 // Templates, journals and other spaces
+)
+
+func (g spaceGrouping) next() spaceGrouping {
+	if g == groupKind {
+		return groupNone
+	}
+	return g + 1
+}
+
+// isJournal reports whether a space is one of Kinopio's daily journals,
+// which are named after their day.
+func isJournal(space Space) bool {
+	_, err := time.Parse(journalNameLayout, space.Name)
+	return err == nil
+}
+
+// spaceCardCount is how many cards a space has, if it's been fetched.
+func (m *model) spaceCardCount(spaceID string) (int, bool) {
+	space, ok := m.spaceCache[spaceID]
+	return len(space.Cards), ok
+}
+
+// sortedSpaces returns the listed spaces in the current sort order. Spaces
+// the order knows nothing about, like ones never visited or fetched, keep
+// their API order after the others.
+func (m *model) sortedSpaces() []Space {
+	spaces := m.listedSpaces()
+	switch m.spaceSort {
+	case spaceSortUpdated:
+		sort.SliceStable(spaces, func(i, j int) bool { return spaces[i].UpdatedAt.After(spaces[j].UpdatedAt) })
+	case spaceSortVisited:
+		rank := func(s Space) int {
+			if i := slices.Index(m.state.RecentSpaces, s.ID); i >= 0 {
+				return i
+			}
+			return len(m.state.RecentSpaces)
+		}
+		sort.SliceStable(spaces, func(i, j int) bool { return rank(spaces[i]) < rank(spaces[j]) })
+	case spaceSortName:
+		sort.SliceStable(spaces, func(i, j int) bool {
+			return strings.ToLower(spaces[i].Name) < strings.ToLower(spaces[j].Name)
+		})
+	case spaceSortCards:
+		count := func(s Space) int {
+			if n, ok := m.spaceCardCount(s.ID); ok {
+				return n
+			}
+			return -1
+		}
+		sort.SliceStable(spaces, func(i, j int) bool { return count(spaces[i]) > count(spaces[j]) })
+	}
+	return spaces
+}
+
+// spaceSortDetail is the field a space is sorted by, for its description
+// in the spaces list.
+func (m *model) spaceSortDetail(space Space) string {
+	switch m.spaceSort {
+	case spaceSortUpdated:
+		return "updated " + relativeTime(space.UpdatedAt)
+	case spaceSortVisited:
+		if slices.Contains(m.state.RecentSpaces, space.ID) {
+			return "visited recently"
+		}
+		return "not visited here"
+	case spaceSortCards:
+		if n, ok := m.spaceCardCount(space.ID); ok {
+			return plural(n, "card")
+		}
+		return "not fetched yet"
+	}
+	return ""
+}
+
+// kindGroupedSpaceItems sections spaces into templates, journals and the
+// rest, keeping the sort order within each section.
+func (m *model) kindGroupedSpaceItems(spaces []Space) []list.Item {
+	var templates, journals, others []Space
+	for _, space := range spaces {
+		switch {
+		case space.IsTemplate:
+			templates = append(templates, space)
+		case isJournal(space):
+			journals = append(journals, space)
+		default:
+			others = append(others, space)
+		}
+	}
+	var items []list.Item
+	for _, group := range []struct {
+		title  string
+		spaces []Space
+	}{{"Templates", templates}, {"Journals", journals}, {"Spaces", others}} {
+		if len(group.spaces) == 0 {
+			continue
+		}
+		items = append(items, sectionItem{group.title})
+		for _, space := range group.spaces {
+			items = append(items, m.spaceItem(space))
+		}
+	}
+	return items
+}
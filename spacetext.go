@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// spaceTextHeader explains the file editSpaceText opens. Lines starting
+// with # are left out when it's read back.
+const spaceTextHeader = `# Each line is a card. Edit a line to change its card, delete it to remove
+# the card, or add a line without an [id] to make a new card. Line breaks in
+# a card are written \n, and backslashes \\. Lines starting with # are
+# ignored.
+`
+
+// spaceTextLine matches what may be a card's line: its ID in brackets,
+// then its text.
+var spaceTextLine = regexp.MustCompile(`^\[([^\]\s]+)\] ?(.*)$`)
+
+var spaceTextEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// escapeCardLine writes a card's text on one line.
+func escapeCardLine(text string) string {
+	return spaceTextEscaper.Replace(text)
+}
+
+// unescapeCardLine undoes escapeCardLine.
+func unescapeCardLine(line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) {
+			switch line[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case '\\':
+				b.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(line[i])
+	}
+	return b.String()
+}
+
+// spaceText writes a space's cards one per line in reading order, each
+// after its ID.
+func spaceText(cards []Card) string {
+	var b strings.Builder
+	b.WriteString(spaceTextHeader)
+	for _, card := range sortCards(cards, sortReading) {
+		fmt.Fprintf(&b, "[%s] %s\n", card.ID, escapeCardLine(card.Name))
+	}
+	return b.String()
+}
+
+// spaceTextDiff is what changed between the cards and an edited copy of
+// their spaceText.
+type spaceTextDiff struct {
+	created []string // Names of new cards
+	updated []Card
+	removed []Card
+}
+
+// diffSpaceText compares edited text with the cards it was written from.
+// Only the IDs of those cards are read as IDs, so a new line like
+// "[x] buy milk" keeps its brackets. A line with an ID that's already
+// been seen, say from copying a line, is a new card.
+func diffSpaceText(cards []Card, text string) spaceTextDiff {
+	var diff spaceTextDiff
+	byID := make(map[string]Card, len(cards))
+	for _, card := range cards {
+		byID[card.ID] = card
+	}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		match := spaceTextLine.FindStringSubmatch(line)
+		card, ok := Card{}, false
+		if match != nil {
+			card, ok = byID[match[1]]
+		}
+		if !ok {
+			diff.created = append(diff.created, unescapeCardLine(line))
+			continue
+		}
+		id, name := match[1], unescapeCardLine(match[2])
+		if seen[id] {
+			diff.created = append(diff.created, name)
+			continue
+		}
+		seen[id] = true
+		if name != card.Name {
+			card.Name = name
+			diff.updated = append(diff.updated, card)
+		}
+	}
+	for _, card := range cards {
+		if !seen[card.ID] {
+			diff.removed = append(diff.removed, card)
+		}
+	}
+	return diff
+}
+
+// editSpaceText opens the space's cards in the user's editor as lines of
+// text, then creates, updates and removes cards to match what was saved.
+// Removing cards is confirmed first.
+func (m *model) editSpaceText() tea.Cmd {
+	spaceID := m.selectedSpace.ID
+	cards := append([]Card(nil), m.selectedSpace.Cards...)
+	return runEditor("kinopio-space-*.txt", spaceText(cards), func(text string) tea.Cmd {
+		if m.selectedSpace.ID != spaceID {
+			return m.toast("The space was closed: your edit wasn't saved")
+		}
+		diff := diffSpaceText(cards, text)
+		if len(diff.created)+len(diff.updated)+len(diff.removed) == 0 {
+			return m.toast("No changes")
+		}
+		if len(diff.removed) == 0 {
+			return m.applySpaceText(diff)
+		}
+		message := fmt.Sprintf("Add %d, change %d and remove %s?\nYou can restore removed cards from Removed cards.",
+			len(diff.created), len(diff.updated), plural(len(diff.removed), "card"))
+		m.askConfirm(message, true, func() tea.Cmd { return m.applySpaceText(diff) })
+		return nil
+	})
+}
+
+// applySpaceText makes the changes from an edited spaceText, as one edit
+// to undo.
+func (m *model) applySpaceText(diff spaceTextDiff) tea.Cmd {
+	var (
+		changes []cardChange
+		cmds    []tea.Cmd
+		fields  []map[string]interface{}
+		updated []Card
+	)
+	for _, card := range diff.updated {
+		before, ok := m.cardByID(card.ID)
+		if !ok {
+			continue
+		}
+		card := card
+		card.NameUpdatedAt = time.Now()
+		changes = append(changes, cardChange{before: &before, after: &card})
+		fields = append(fields, map[string]interface{}{"id": card.ID, "name": card.Name})
+		updated = append(updated, card)
+	}
+	for _, card := range diff.removed {
+		card := card
+		changes = append(changes, cardChange{before: &card})
+		m.removeLocalCard(card.ID)
+		cmds = append(cmds, removeCard(card.ID))
+	}
+	if len(diff.created) > 0 {
+		x, y := nextCardPosition(m.selectedSpace.Cards)
+		created := make([]Card, len(diff.created))
+		for i, name := range diff.created {
+			created[i] = Card{ID: newID(), Name: name, X: x, Y: y + i*bulkAddSpacing}
+			changes = append(changes, cardChange{after: &created[i]})
+		}
+		m.selectedSpace.Cards = append(m.selectedSpace.Cards, created...)
+		cmds = append(cmds, createCards(m.selectedSpace.ID, created))
+	}
+	if len(fields) > 0 {
+		cmds = append(cmds, updateCards(fields))
+	}
+	m.recordEdit("text edit", changes)
+	m.replaceCards(updated...)
+	m.refreshSpaceView()
+	summary := fmt.Sprintf("Added %d, changed %d, removed %d", len(diff.created), len(updated), len(diff.removed))
+	return tea.Batch(append(cmds, m.toast(summary))...)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSpaceText(t *testing.T) {
+	a := Card{ID: "V1StGXR8_Z5jdHi6B-myT", Name: "first"}
+	b := Card{ID: "3H7n0hNuLw0tEhfQ5ZlK0", Name: "second\nline"}
+	cards := []Card{a, b}
+	renamed := a
+	renamed.Name = "first, edited"
+
+	tests := []struct {
+		name string
+		text string
+		want spaceTextDiff
+	}{
+		{
+			name: "unchanged",
+			text: spaceText(cards),
+		},
+		{
+			name: "edited and removed",
+			text: "# comment\n[" + a.ID + "] first, edited\n",
+			want: spaceTextDiff{updated: []Card{renamed}, removed: []Card{b}},
+		},
+		{
+			name: "escapes",
+			text: "[" + a.ID + "] first\n[" + b.ID + "] second\\nline\nback\\\\slash\n",
+			want: spaceTextDiff{created: []string{`back\slash`}},
+		},
+		{
+			name: "brackets that aren't card IDs",
+			text: spaceText(cards) + "[x] buy milk\n[] open task\n[see] note\n[" + "Zzzzzzzzzzzzzzzzzzzzz" + "] unknown ID\n",
+			want: spaceTextDiff{created: []string{"[x] buy milk", "[] open task", "[see] note", "[Zzzzzzzzzzzzzzzzzzzzz] unknown ID"}},
+		},
+		{
+			name: "a copied line is a new card",
+			text: spaceText(cards) + "[" + a.ID + "] copy of first\n",
+			want: spaceTextDiff{created: []string{"copy of first"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffSpaceText(cards, tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffSpaceText() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardLineEscaping(t *testing.T) {
+	for _, text := range []string{"", "plain", "two\nlines", `back\slash`, `\n literally`, "trailing\\"} {
+		if got := unescapeCardLine(escapeCardLine(text)); got != text {
+			t.Errorf("unescapeCardLine(escapeCardLine(%q)) = %q", text, got)
+		}
+	}
+}
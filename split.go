@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Bounds of the list pane's share of the width in the split layout, in
+// percent, and how much ctrl+arrows change it.
+const (
+	defaultSplitPercent = 40
+	minSplitPercent     = 20
+	maxSplitPercent     = 80
+	splitStep           = 5
+)
+
+// minPaneWidth is the narrowest either pane gets side by side. Terminals
+// narrower than previewBesideWidth show the preview below the list
+// instead, in previewHeight lines.
+const (
+	minPaneWidth       = 24
+	previewBesideWidth = 80
+	previewHeight      = 6
+)
+
+// previewCards is how many of a space's cards its preview lists.
+const previewCards = 8
+
+// splitActive reports whether the current view is shown with a preview
+// pane.
+func (m *model) splitActive() bool {
+	return m.state.Split && (m.currentView == "list" || m.currentView == "cards")
+}
+
+// previewBelow reports whether the preview pane goes under the list,
+// because the terminal is too narrow to put it beside it.
+func (m *model) previewBelow() bool {
+	return m.width < previewBesideWidth
+}
+
+// listPaneWidth is how wide the list is in the split layout.
+func (m *model) listPaneWidth() int {
+	if m.previewBelow() {
+		return m.width
+	}
+	percent := m.state.SplitPercent
+	if percent == 0 {
+		percent = defaultSplitPercent
+	}
+	return min(max(m.width*percent/100, minPaneWidth), m.width-minPaneWidth)
+}
+
+// toggleSplit turns the preview pane on or off, remembering the choice.
+func (m *model) toggleSplit() error {
+	m.state.Split = !m.state.Split
+	m.resize()
+	return m.state.save()
+}
+
+// resizeSplit moves the divider between the panes by step percent.
+func (m *model) resizeSplit(step int) error {
+	percent := m.state.SplitPercent
+	if percent == 0 {
+		percent = defaultSplitPercent
+	}
+	m.state.SplitPercent = min(max(percent+step, minSplitPercent), maxSplitPercent)
+	m.resize()
+	return m.state.save()
+}
+
+// splitView puts the list and a preview of its selected item side by
+// side, or one above the other.
+func (m *model) splitView() string {
+	if m.previewBelow() {
+		preview := previewBelowStyle.
+			Width(m.width).
+			Height(previewHeight).
+			MaxHeight(previewHeight + previewBelowStyle.GetVerticalFrameSize()).
+			Render(m.preview(m.width))
+		return lipgloss.JoinVertical(lipgloss.Left, m.list.View(), preview)
+	}
+	width := m.width - m.listPaneWidth() - previewStyle.GetHorizontalFrameSize()
+	preview := previewStyle.
+		Width(width + previewStyle.GetPaddingLeft()).
+		Height(m.list.Height()).
+		MaxHeight(m.list.Height()).
+		Render(m.preview(width))
+	return lipgloss.JoinHorizontal(lipgloss.Top, m.list.View(), preview)
+}
+
+// preview describes the list's selected item in width columns.
+func (m *model) preview(width int) string {
+	wrap := lipgloss.NewStyle().Width(width)
+	switch item := m.list.SelectedItem().(type) {
+	case listItem:
+		return wrap.Render(m.spacePreview(item.Space))
+	case cardListItem:
+		return m.cardText(item.Card.Name, width) + "\n\n" + wrap.Render(m.cardPreview(item))
+	}
+	return previewLabelStyle.Render("Nothing selected")
+}
+
+// previewField renders a labelled line of a preview.
+func previewField(label, value string) string {
+	return previewLabelStyle.Render(label+": ") + value
+}
+
+// spacePreview summarizes a space, with its contents once it's been
+// fetched.
+func (m *model) spacePreview(space Space) string {
+	lines := []string{headerNameStyle.Render(space.Name), fmt.Sprintf("https://kinopio.club/%s", space.Url), ""}
+	fetched, ok := m.spaceCache[space.ID]
+	if space.ID == m.selectedSpace.ID {
+		fetched, ok = m.selectedSpace, true
+	}
+	if !ok {
+		return strings.Join(append(lines, previewLabelStyle.Render("Not fetched yet. Press enter to open it.")), "\n")
+	}
+
+	if fetched.Privacy != "" {
+		lines = append(lines, previewField("privacy", fetched.Privacy))
+	}
+	boxes := displaySettings.formatNumber(len(fetched.Boxes)) + " boxes"
+	if len(fetched.Boxes) == 1 {
+		boxes = "1 box"
+	}
+	lines = append(lines,
+		previewField("updated", relativeTime(fetched.UpdatedAt)),
+		previewField("contents", strings.Join([]string{
+			plural(len(fetched.Cards), "card"),
+			boxes,
+			plural(len(fetched.Connections), "connection"),
+		}, ", ")),
+	)
+	var tags []string
+	for _, tag := range spaceTags(fetched) {
+		tags = append(tags, "[["+tag.name+"]]")
+	}
+	if len(tags) > 0 {
+		lines = append(lines, previewField("tags", strings.Join(tags, " ")))
+	}
+	if len(fetched.Cards) > 0 {
+		lines = append(lines, "")
+		for i, card := range sortCards(fetched.Cards, sortReading) {
+			if i == previewCards {
+				lines = append(lines, previewLabelStyle.Render(fmt.Sprintf("and %d more", len(fetched.Cards)-previewCards)))
+				break
+			}
+			lines = append(lines, "• "+firstLine(card.Name))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cardPreview shows a card's color, position, tags and connections, to go
+// under its whole text, which the list truncates.
+func (m *model) cardPreview(item cardListItem) string {
+	card := item.Card
+	color := card.BackgroundColor
+	if color == "" {
+		color = defaultCardColor
+	}
+	position := fmt.Sprintf("%d, %d", card.X, card.Y)
+	if item.boxName != "" {
+		position += " in " + item.boxName
+	}
+	lines := []string{
+		previewField("color", lipgloss.NewStyle().Background(lipgloss.Color(color)).Render("    ")+" "+color),
+		previewField("position", position),
+	}
+	if tags := cardTags(card.Name); len(tags) > 0 {
+		lines = append(lines, previewField("tags", strings.Join(tags, ", ")))
+	}
+	if urls := cardURLs(card.Name); len(urls) > 0 {
+		lines = append(lines, previewField("links", strings.Join(urls, " ")))
+	}
+	if rows := m.cardConnectionRows(card); len(rows) > 0 {
+		lines = append(lines, previewLabelStyle.Render("connections:"))
+		for _, row := range rows {
+			lines = append(lines, "  "+row[0]+" "+row[1])
+		}
+	}
+	lines = append(lines, previewField("updated", relativeTime(card.UpdatedAt)))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// localState is data the TUI keeps between runs. It lives in the user's
+// config directory as JSON.
+type localState struct {
+	WarmSpaces   []string            `json:"warmSpaces,omitempty"`
+	RecentSpaces []string            `json:"recentSpaces,omitempty"` // Most recently opened first
+	Pins         map[string][]string `json:"pins,omitempty"`         // Pinned card IDs, keyed by space ID
+	Split        bool                `json:"split,omitempty"`        // Show a preview pane beside the list
+	SplitPercent int                 `json:"splitPercent,omitempty"` // The list pane's share of the width
+	LastSpace    string              `json:"lastSpace,omitempty"`    // The space open on quitting, for --resume
+	LastCard     string              `json:"lastCard,omitempty"`     // The card under the cursor on quitting
+	LastCardOpen bool                `json:"lastCardOpen,omitempty"` // Whether that card's details were open
+}
+
+func statePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kinopio-tui", "state.json"), nil
+}
+
+// loadState reads the state file. A missing file is an empty state.
+func loadState() (localState, error) {
+	var state localState
+	path, err := statePath()
+	if err != nil {
+		return state, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("error reading state file: %v", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("error parsing state file %s: %v", path, err)
+	}
+	return state, nil
+}
+
+func (s localState) save() error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating state directory: %v", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	return nil
+}
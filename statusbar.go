@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toastDuration is how long a toast stays in the status bar.
+const toastDuration = 3 * time.Second
+
+// userMsg carries the signed-in user, for the status bar.
+type userMsg struct {
+	id, name string
+}
+
+// toastExpiredMsg clears a toast, unless a newer one replaced it.
+type toastExpiredMsg struct {
+	seq int
+}
+
+// fetchUser looks up who the API key belongs to. The status bar just
+// leaves the name out if that fails.
+func fetchUser() tea.Cmd {
+	return func() tea.Msg {
+		user, err := api.CurrentUser(programCtx)
+		if err != nil {
+			return nil
+		}
+		return userMsg{id: user.ID, name: user.Name}
+	}
+}
+
+// toast shows a short message in the status bar for a few seconds, e.g.
+// "Card created".
+func (m *model) toast(text string) tea.Cmd {
+	m.toastSeq++
+	m.toastText = text
+	seq := m.toastSeq
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg { return toastExpiredMsg{seq} })
+}
+
+func (m *model) toastExpired(msg toastExpiredMsg) {
+	if msg.seq == m.toastSeq {
+		m.toastText = ""
+	}
+}
+
+// statusBarView renders the bar along the bottom: where you are on the
+// left, and any toast, the user and the sync state on the right.
+func (m *model) statusBarView() string {
+	plain := statusBarStyle.UnsetPadding()
+	var left []string
+	if m.inSpace() && m.selectedSpace.ID != "" {
+		left = append(left, m.selectedSpace.Name, plural(len(m.selectedSpace.Cards), "card"))
+	} else {
+		left = append(left, plural(len(m.spaces), "space"))
+	}
+
+	var right []string
+	if m.toastText != "" {
+		right = append(right, toastStyle.Render(m.toastText))
+	}
+	if n := m.unreadNotifications(); n > 0 {
+		right = append(right, toastStyle.Render(fmt.Sprintf("✉ %d unread", n)))
+	}
+	if m.accountName != "" {
+		right = append(right, plain.Render(m.accountName))
+	}
+	if status := m.syncStatus(); status != "" {
+		right = append(right, offlineStyle.Render(status))
+	} else {
+		right = append(right, plain.Render("online"))
+	}
+
+	leftText := plain.Render(strings.Join(left, " · "))
+	rightText := strings.Join(right, plain.Render(" · "))
+	gap := max(m.width-2-lipgloss.Width(leftText)-lipgloss.Width(rightText), 1)
+	return statusBarStyle.Width(m.width).MaxWidth(m.width).Render(leftText + plain.Render(strings.Repeat(" ", gap)) + rightText)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+const switcherMaxResults = 10
+
+// spaceSwitcher is an overlay for jumping to any space by fuzzy-matching
+// its name, so switching doesn't require backing out to the spaces list.
+type spaceSwitcher struct {
+	input   textinput.Model
+	spaces  []Space
+	matches fuzzy.Matches
+	cursor  int
+
+	// onPick runs with the chosen space. When it's nil the space's cards
+	// are opened.
+	onPick func(Space) tea.Cmd
+}
+
+func newSpaceSwitcher(spaces []Space) *spaceSwitcher {
+	input := textinput.New()
+	input.Placeholder = "Jump to space…"
+	input.Focus()
+	s := &spaceSwitcher{input: input, spaces: spaces}
+	s.filter()
+	return s
+}
+
+func (s *spaceSwitcher) String(i int) string { return s.spaces[i].Name }
+func (s *spaceSwitcher) Len() int            { return len(s.spaces) }
+
+// filter recomputes the matches for the current query. An empty query
+// matches every space in its original order.
+func (s *spaceSwitcher) filter() {
+	query := s.input.Value()
+	if query == "" {
+		s.matches = make(fuzzy.Matches, len(s.spaces))
+		for i, space := range s.spaces {
+			s.matches[i] = fuzzy.Match{Str: space.Name, Index: i}
+		}
+	} else {
+		s.matches = fuzzy.FindFrom(query, s)
+	}
+	s.cursor = 0
+}
+
+// Update handles a key press. It reports whether the switcher is finished
+// and, if a space was picked, which one.
+func (s *spaceSwitcher) Update(msg tea.KeyMsg) (bool, *Space, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return true, nil, nil
+	case "enter":
+		if len(s.matches) == 0 {
+			return true, nil, nil
+		}
+		space := s.spaces[s.matches[s.cursor].Index]
+		return true, &space, nil
+	case "up", "ctrl+p":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+		return false, nil, nil
+	case "down", "ctrl+n":
+		if s.cursor < min(len(s.matches), switcherMaxResults)-1 {
+			s.cursor++
+		}
+		return false, nil, nil
+	}
+	var cmd tea.Cmd
+	query := s.input.Value()
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != query {
+		s.filter()
+	}
+	return false, nil, cmd
+}
+
+func (s *spaceSwitcher) View(width int) string {
+	width = min(60, width-4)
+	normal := lipgloss.NewStyle().Padding(0, 1)
+	selected := normal.Foreground(theme.OnAccent).Background(theme.Accent)
+	match := lipgloss.NewStyle().Underline(true).Bold(true)
+
+	lines := []string{s.input.View(), ""}
+	for i, m := range s.matches {
+		if i >= switcherMaxResults {
+			break
+		}
+		style := normal
+		if i == s.cursor {
+			style = selected
+		}
+		name := lipgloss.StyleRunes(m.Str, m.MatchedIndexes, match.Inherit(style.Inline(true)), style.Inline(true))
+		lines = append(lines, style.Width(width-4).MaxWidth(width-4).Render(name))
+	}
+	if len(s.matches) == 0 {
+		lines = append(lines, dimStyle().Render("No matching spaces"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// updateSwitcher forwards a key press to the open switcher and opens the
+// chosen space's cards.
+func (m *model) updateSwitcher(msg tea.KeyMsg) tea.Cmd {
+	done, space, cmd := m.switcher.Update(msg)
+	if !done {
+		return cmd
+	}
+	onPick := m.switcher.onPick
+	m.switcher = nil
+	if space == nil {
+		return nil
+	}
+	if onPick != nil {
+		return onPick(*space)
+	}
+	return m.openSpaceCards(*space)
+}
+
+// switcherSpaces lists the spaces for the switcher, the recently visited
+// ones first.
+func (m *model) switcherSpaces() []Space {
+	recent := m.recentSpaces()
+	spaces := slices.Clone(recent)
+	for _, space := range m.spaces {
+		if !slices.ContainsFunc(recent, func(s Space) bool { return s.ID == space.ID }) {
+			spaces = append(spaces, space)
+		}
+	}
+	return spaces
+}
+
+// openSpaceCards opens a space straight to its cards.
+func (m *model) openSpaceCards(space Space) tea.Cmd {
+	m.pendingLocation = &location{view: "cards", spaceID: space.ID}
+	return m.openSpace(space)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tagCount is a tag and how many of a space's cards use it.
+type tagCount struct {
+	name  string
+	color string
+	count int
+}
+
+type userTagsMsg struct {
+	tags []Tag
+}
+
+type taggedCardsMsg struct {
+	tag   string
+	cards []Card
+}
+
+// spaceTags counts the tags in a space, from both its tags list and the
+// [[tag]]s in card names, most used first.
+func spaceTags(space Space) []tagCount {
+	cardsByTag := map[string]map[string]bool{}
+	colors := map[string]string{}
+	add := func(tag, cardID string) {
+		if cardsByTag[tag] == nil {
+			cardsByTag[tag] = map[string]bool{}
+		}
+		cardsByTag[tag][cardID] = true
+	}
+	for _, tag := range space.Tags {
+		add(tag.Name, tag.CardID)
+		colors[tag.Name] = tag.Color
+	}
+	for _, card := range space.Cards {
+		for _, tag := range cardTags(card.Name) {
+			add(tag, card.ID)
+		}
+	}
+
+	tags := make([]tagCount, 0, len(cardsByTag))
+	for name, cards := range cardsByTag {
+		tags = append(tags, tagCount{name: name, color: colors[name], count: len(cards)})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].count != tags[j].count {
+			return tags[i].count > tags[j].count
+		}
+		return strings.ToLower(tags[i].name) < strings.ToLower(tags[j].name)
+	})
+	return tags
+}
+
+// hasTag reports whether a card is tagged with name, in its text or in
+// the space's tags list.
+func (m *model) hasTag(card Card, name string) bool {
+	for _, tag := range cardTags(card.Name) {
+		if tag == name {
+			return true
+		}
+	}
+	for _, tag := range m.selectedSpace.Tags {
+		if tag.CardID == card.ID && tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) showTags() {
+	m.currentView = "tags"
+	m.list.Title = m.selectedSpace.Name + " → Tags"
+	tags := spaceTags(m.selectedSpace)
+	items := make([]list.Item, len(tags))
+	for i, tag := range tags {
+		items[i] = tagListItem{name: tag.name, description: plural(tag.count, "card")}
+	}
+	m.setItems(items)
+}
+
+// showTaggedCards lists the space's cards that have a tag.
+func (m *model) showTaggedCards(tag string) {
+	m.boxFilter = nil
+	m.tagFilter = tag
+	m.showCards()
+}
+
+// openUserTags fetches the tags used across all spaces.
+func (m *model) openUserTags() tea.Cmd {
+	m.loading = true
+	return tea.Batch(func() tea.Msg {
+		tags, err := api.UserTags(programCtx)
+		if err != nil {
+			return err
+		}
+		return userTagsMsg{tags: tags}
+	}, m.spinner.Tick)
+}
+
+func (m *model) showUserTags(tags []Tag) {
+	m.currentView = "allTags"
+	m.list.Title = "Tags"
+	seen := map[string]bool{}
+	var items []list.Item
+	for _, tag := range tags {
+		if !seen[tag.Name] {
+			seen[tag.Name] = true
+			items = append(items, tagListItem{name: tag.Name, description: "Find cards in every space"})
+		}
+	}
+	m.setItems(items)
+}
+
+// openTaggedCards fetches the cards with a tag, across all spaces.
+func (m *model) openTaggedCards(tag string) tea.Cmd {
+	m.loading = true
+	return tea.Batch(func() tea.Msg {
+		cards, err := api.CardsByTag(programCtx, tag)
+		if err != nil {
+			return err
+		}
+		return taggedCardsMsg{tag: tag, cards: cards}
+	}, m.spinner.Tick)
+}
+
+func (m *model) showTaggedCardsEverywhere(tag string, cards []Card) {
+	m.currentView = "tagCards"
+	m.list.Title = fmt.Sprintf("Tags → [[%s]] (%s)", tag, plural(len(cards), "card"))
+	spaceNames := map[string]string{}
+	for _, space := range m.spaces {
+		spaceNames[space.ID] = space.Name
+	}
+	items := make([]list.Item, len(cards))
+	for i, card := range cards {
+		items[i] = taggedCardItem{Card: card, spaceName: spaceNames[card.SpaceID]}
+	}
+	m.setItems(items)
+}
+
+// openTaggedCard opens a card found by tag in its own space.
+func (m *model) openTaggedCard(card Card) tea.Cmd {
+	m.pendingLocation = &location{view: "cardDetails", spaceID: card.SpaceID, cardID: card.ID}
+	return m.openSpace(Space{ID: card.SpaceID})
+}
+
+type tagListItem struct {
+	name        string
+	description string
+}
+
+func (i tagListItem) FilterValue() string { return i.name }
+func (i tagListItem) Title() string       { return "[[" + i.name + "]]" }
+func (i tagListItem) Description() string { return i.description }
+
+type taggedCardItem struct {
+	Card      Card
+	spaceName string
+}
+
+func (i taggedCardItem) FilterValue() string { return i.Card.Name }
+func (i taggedCardItem) Title() string       { return firstLine(i.Card.Name) }
+func (i taggedCardItem) Description() string {
+	return "in " + orNone(i.spaceName)
+}
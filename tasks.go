@@ -0,0 +1,63 @@
+package main
+
+import (
+	"regexp"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// checkboxPattern matches the checkbox Kinopio puts at the start of a task
+// card's name: "[] " when open, "[x] " when done.
+var checkboxPattern = regexp.MustCompile(`^\[([xX ]?)\]\s?`)
+
+// cardCheckbox reports whether a card is a task, and if so whether it's
+// done.
+func cardCheckbox(name string) (isTask, done bool) {
+	match := checkboxPattern.FindStringSubmatch(name)
+	if match == nil {
+		return false, false
+	}
+	return true, match[1] == "x" || match[1] == "X"
+}
+
+// setCheckbox rewrites a task card's checkbox to done or open.
+func setCheckbox(name string, done bool) string {
+	box := "[] "
+	if done {
+		box = "[x] "
+	}
+	return box + checkboxPattern.ReplaceAllString(name, "")
+}
+
+// toggleTask checks or unchecks the selected task card.
+func (m *model) toggleTask() tea.Cmd {
+	card, ok := m.selectedListCard()
+	if !ok {
+		return nil
+	}
+	isTask, done := cardCheckbox(card.Name)
+	if !isTask {
+		return nil
+	}
+	card.Name = setCheckbox(card.Name, !done)
+	card.NameUpdatedAt = time.Now()
+	m.recordUpdate("task check", card)
+	m.replaceCards(card)
+	return updateCard(map[string]interface{}{"id": card.ID, "name": card.Name})
+}
+
+// openTaskFilter keeps only unfinished task cards when openTasksOnly is
+// set.
+func (m *model) openTaskFilter(cards []Card) []Card {
+	if !m.openTasksOnly {
+		return cards
+	}
+	var open []Card
+	for _, card := range cards {
+		if isTask, done := cardCheckbox(card.Name); isTask && !done {
+			open = append(open, card)
+		}
+	}
+	return open
+}
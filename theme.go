@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// palette is the set of colors the UI is drawn with. Each theme is one,
+// and the [colors] table in the config file overrides single colors of
+// it, using the same names.
+type palette struct {
+	Text     lipgloss.Color `toml:"text"`
+	Dim      lipgloss.Color `toml:"dim"`       // Hints and secondary text
+	Accent   lipgloss.Color `toml:"accent"`    // Selected rows, focused borders and buttons
+	OnAccent lipgloss.Color `toml:"on_accent"` // Text on Accent
+	Title    lipgloss.Color `toml:"title"`     // Section and column titles, the list's cursor
+	Border   lipgloss.Color `toml:"border"`
+	Bar      lipgloss.Color `toml:"bar"`      // The header and status bar
+	BarText  lipgloss.Color `toml:"bar_text"` // Text on Bar
+	Strong   lipgloss.Color `toml:"strong"`   // Names and notes that stand out on Bar
+	Warning  lipgloss.Color `toml:"warning"`
+	Danger   lipgloss.Color `toml:"danger"`   // Errors and destructive choices
+	Match    lipgloss.Color `toml:"match"`    // Behind the parts of names that match a search
+	OnMatch  lipgloss.Color `toml:"on_match"` // Text on Match
+}
+
+var themes = map[string]palette{
+	"dark": {
+		Text:     "252",
+		Dim:      "241",
+		Accent:   "57",
+		OnAccent: "229",
+		Title:    "212",
+		Border:   "240",
+		Bar:      "236",
+		BarText:  "250",
+		Strong:   "230",
+		Warning:  "214",
+		Danger:   "196",
+		Match:    "#f5d76e",
+		OnMatch:  "#1a1a1a",
+	},
+	"light": {
+		Text:     "235",
+		Dim:      "244",
+		Accent:   "#6c4ee6",
+		OnAccent: "#ffffff",
+		Title:    "#c02680",
+		Border:   "250",
+		Bar:      "254",
+		BarText:  "238",
+		Strong:   "232",
+		Warning:  "#b35c00",
+		Danger:   "#c0002b",
+		Match:    "#f5d76e",
+		OnMatch:  "#1a1a1a",
+	},
+	"high-contrast": {
+		Text:     "15",
+		Dim:      "250",
+		Accent:   "11",
+		OnAccent: "0",
+		Title:    "14",
+		Border:   "15",
+		Bar:      "0",
+		BarText:  "15",
+		Strong:   "11",
+		Warning:  "11",
+		Danger:   "9",
+		Match:    "11",
+		OnMatch:  "0",
+	},
+}
+
+// theme is the palette in use. setTheme changes it.
+var theme = themes["dark"]
+
+// Styles shared between views, built from the theme by setTheme.
+var (
+	headerStyle       lipgloss.Style
+	headerNameStyle   lipgloss.Style
+	statusBarStyle    lipgloss.Style
+	toastStyle        lipgloss.Style
+	offlineStyle      lipgloss.Style
+	sectionStyle      lipgloss.Style
+	previewStyle      lipgloss.Style
+	previewBelowStyle lipgloss.Style
+	previewLabelStyle lipgloss.Style
+)
+
+func init() {
+	setTheme(theme)
+}
+
+// themeNamed looks up a theme by its name in the config file. auto picks
+// dark or light to suit the terminal's background.
+func themeNamed(name string) (palette, error) {
+	name = strings.ToLower(name)
+	switch name {
+	case "", "auto":
+		if lipgloss.HasDarkBackground() {
+			return themes["dark"], nil
+		}
+		return themes["light"], nil
+	case "dark", "high-contrast":
+		lipgloss.SetHasDarkBackground(true)
+	case "light":
+		lipgloss.SetHasDarkBackground(false)
+	default:
+		return palette{}, fmt.Errorf("unknown theme %q: use auto, dark, light or high-contrast", name)
+	}
+	return themes[name], nil
+}
+
+// with returns p with the colors set in overrides replacing its own.
+func (p palette) with(overrides palette) palette {
+	to, from := reflect.ValueOf(&p).Elem(), reflect.ValueOf(overrides)
+	for i := 0; i < from.NumField(); i++ {
+		if from.Field(i).String() != "" {
+			to.Field(i).Set(from.Field(i))
+		}
+	}
+	return p
+}
+
+// setTheme switches to a palette, restyling the styles shared between
+// views. Views build their other styles from theme as they render.
+func setTheme(p palette) {
+	theme = p
+	headerStyle = lipgloss.NewStyle().Foreground(p.BarText).Background(p.Bar).Padding(0, 1)
+	headerNameStyle = lipgloss.NewStyle().Bold(true).Foreground(p.Strong)
+	statusBarStyle = lipgloss.NewStyle().Foreground(p.BarText).Background(p.Bar).Padding(0, 1)
+	toastStyle = lipgloss.NewStyle().Foreground(p.Strong).Background(p.Bar).Bold(true)
+	offlineStyle = lipgloss.NewStyle().Foreground(p.Warning).Background(p.Bar)
+	sectionStyle = lipgloss.NewStyle().Bold(true).Foreground(p.Title).Padding(1, 0, 0, 2)
+	previewStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderLeft(true).BorderForeground(p.Border).PaddingLeft(1)
+	previewBelowStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderTop(true).BorderForeground(p.Border)
+	previewLabelStyle = lipgloss.NewStyle().Foreground(p.Dim)
+}
+
+// restyle applies the current theme to the list, which keeps copies of
+// its styles.
+func (m *model) restyle() {
+	m.list.SetDelegate(newItemDelegate())
+	m.list.Styles.Title = m.list.Styles.Title.Foreground(theme.OnAccent).Background(theme.Accent)
+}
+
+// dimStyle is for hints and other secondary text.
+func dimStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Dim)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// transferOverlap is how close, in canvas pixels, a card can land to one
+// already in the target space before it's treated as a collision.
+const transferOverlap = 40
+
+// cardsTransferredMsg reports cards copied or moved to another space. If
+// a move failed partway, cards are the ones that moved and err says why
+// the rest didn't. Restacked names the cards that couldn't keep their
+// positions.
+type cardsTransferredMsg struct {
+	cards     []Card
+	copies    []Card
+	restacked []string
+	sourceID  string
+	target    Space
+	moved     bool
+	err       error
+}
+
+// cardsToTransfer returns the selection, or the highlighted card if
+// nothing is selected.
+func (m *model) cardsToTransfer() []Card {
+	if cards := m.selectedCards(); len(cards) > 0 {
+		return cards
+	}
+	if card, ok := m.selectedListCard(); ok {
+		return []Card{card}
+	}
+	return nil
+}
+
+// pickTransferTarget opens the space picker to move or copy cards to
+// another space.
+func (m *model) pickTransferTarget(move bool) {
+	cards := m.cardsToTransfer()
+	if len(cards) == 0 {
+		return
+	}
+	verb := "Copy"
+	if move {
+		verb = "Move"
+	}
+	var others []Space
+	for _, space := range m.spaces {
+		if space.ID != m.selectedSpace.ID {
+			others = append(others, space)
+		}
+	}
+	m.switcher = newSpaceSwitcher(others)
+	m.switcher.input.Placeholder = fmt.Sprintf("%s %s to…", verb, plural(len(cards), "card"))
+	m.switcher.onPick = func(target Space) tea.Cmd {
+		m.loading = true
+		return tea.Batch(transferCards(cards, m.selectedSpace.ID, target, move), m.spinner.Tick)
+	}
+}
+
+// transferCards copies cards into the target space, then removes the
+// originals when moving. Cards keep their positions unless that would
+// land them on a card already there, in which case they're stacked near
+// the top-left of the target instead. If removing an original fails, the
+// copies of the cards not yet removed are taken back out of the target,
+// so that each card ends up in just one space.
+func transferCards(cards []Card, sourceID string, target Space, move bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := programCtx
+		target, err := api.Space(ctx, target.ID)
+		if err != nil {
+			return err
+		}
+
+		copies := make([]Card, len(cards))
+		var restacked []string
+		for i, card := range cards {
+			copies[i] = card
+			copies[i].ID = newID()
+			if collides(card, target.Cards) {
+				copies[i].X = importOriginX
+				copies[i].Y = importOriginY + len(restacked)*bulkAddSpacing
+				restacked = append(restacked, firstLine(card.Name))
+			}
+		}
+		if err := api.CreateCards(ctx, target.ID, copies); err != nil {
+			return err
+		}
+		msg := cardsTransferredMsg{cards: cards, copies: copies, restacked: restacked, sourceID: sourceID, target: target, moved: move}
+		if move {
+			for i, card := range cards {
+				if err := api.RemoveCard(ctx, card.ID); err != nil {
+					for _, created := range copies[i:] {
+						api.RemoveCard(ctx, created.ID) //nolint: errcheck
+					}
+					msg.cards, msg.copies, msg.err = cards[:i], copies[:i], err
+					break
+				}
+			}
+		}
+		return msg
+	}
+}
+
+// collides reports whether card sits on top of any of others.
+func collides(card Card, others []Card) bool {
+	for _, other := range others {
+		if abs(card.X-other.X) < transferOverlap && abs(card.Y-other.Y) < transferOverlap {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// cardsTransferred drops moved cards from the source space and records
+// the move to undo. If the move failed partway, it says how many cards
+// moved and fetches the source space afresh.
+func (m *model) cardsTransferred(msg cardsTransferredMsg) tea.Cmd {
+	m.loading = false
+	if !msg.moved {
+		return m.toast("Copied " + plural(len(msg.cards), "card") + " to " + msg.target.Name + restackedNote(msg.restacked))
+	}
+	if len(msg.cards) > 0 {
+		changes := make([]cardChange, len(msg.cards))
+		copies := make([]string, len(msg.copies))
+		for i := range msg.cards {
+			changes[i] = cardChange{before: &msg.cards[i]}
+			copies[i] = msg.copies[i].ID
+		}
+		m.pushEdit(undoEdit{label: "move to " + msg.target.Name, spaceID: msg.sourceID, changes: changes, copies: copies})
+	}
+	if m.selectedSpace.ID == msg.sourceID {
+		for _, card := range msg.cards {
+			m.removeLocalCard(card.ID)
+		}
+		m.refreshSpaceView()
+	}
+	if msg.err != nil {
+		err := fmt.Errorf("moved %d of the cards to %s, then: %w", len(msg.cards), msg.target.Name, msg.err)
+		return tea.Batch(m.showError(err), refreshSpace(msg.sourceID))
+	}
+	return m.toast("Moved " + plural(len(msg.cards), "card") + " to " + msg.target.Name + restackedNote(msg.restacked))
+}
+
+// restackedNote tells the user which cards landed at the top left of the
+// target because their spots were taken, so they know to look there.
+func restackedNote(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("; %q is at the top left, its spot was taken", names[0])
+	default:
+		quoted := make([]string, len(names))
+		for i, name := range names {
+			quoted[i] = strconv.Quote(name)
+		}
+		return fmt.Sprintf("; %d are at the top left, their spots were taken: %s", len(names), strings.Join(quoted, ", "))
+	}
+}
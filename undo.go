@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoLimit is how many edits are kept to undo.
+const undoLimit = 100
+
+// cardChange is a card before and after an edit. A nil before means the
+// edit created the card; a nil after means it removed it.
+type cardChange struct {
+	before, after *Card
+}
+
+// undoEdit is one user action, which may have changed several cards.
+type undoEdit struct {
+	label   string // e.g. "name edit", shown when it's undone
+	spaceID string
+	changes []cardChange
+	copies  []string // Cards the edit created in other spaces, like a move's
+}
+
+// recordEdit adds an edit to the undo history. A new edit can't be redone
+// over, so it clears the redo history.
+func (m *model) recordEdit(label string, changes []cardChange) {
+	if len(changes) == 0 {
+		return
+	}
+	m.pushEdit(undoEdit{label: label, spaceID: m.selectedSpace.ID, changes: changes})
+}
+
+// pushEdit adds an edit, perhaps to a space other than the open one, to
+// the undo history.
+func (m *model) pushEdit(edit undoEdit) {
+	m.undoStack = append(m.undoStack, edit)
+	if len(m.undoStack) > undoLimit {
+		m.undoStack = m.undoStack[len(m.undoStack)-undoLimit:]
+	}
+	m.redoStack = nil
+}
+
+// recordUpdate records cards about to replace their local copies. Call it
+// before replaceCards, while the old copies are still there.
+func (m *model) recordUpdate(label string, cards ...Card) {
+	var changes []cardChange
+	for _, card := range cards {
+		if before, ok := m.cardByID(card.ID); ok {
+			changes = append(changes, cardChange{before: &before, after: &card})
+		}
+	}
+	m.recordEdit(label, changes)
+}
+
+func (m *model) recordCreate(label string, cards ...Card) {
+	changes := make([]cardChange, len(cards))
+	for i := range cards {
+		changes[i] = cardChange{after: &cards[i]}
+	}
+	m.recordEdit(label, changes)
+}
+
+func (m *model) recordRemove(label string, cards ...Card) {
+	changes := make([]cardChange, len(cards))
+	for i := range cards {
+		changes[i] = cardChange{before: &cards[i]}
+	}
+	m.recordEdit(label, changes)
+}
+
+// recordMove records a card being nudged. Nudges in a row are one edit,
+// so undo puts the card back where it started.
+func (m *model) recordMove(card Card) {
+	if n := len(m.undoStack); n > 0 {
+		last := &m.undoStack[n-1]
+		if last.label == "move" && len(last.changes) == 1 && last.changes[0].after != nil && last.changes[0].after.ID == card.ID {
+			last.changes[0].after = &card
+			m.redoStack = nil
+			return
+		}
+	}
+	m.recordUpdate("move", card)
+}
+
+// undo reverts the latest edit made in the selected space.
+func (m *model) undo() tea.Cmd {
+	edit, ok := popEdit(&m.undoStack, m.selectedSpace.ID)
+	if !ok {
+		return m.toast("Nothing to undo")
+	}
+	m.redoStack = append(m.redoStack, edit)
+	cmd := m.applyChanges(edit.changes, true)
+	return tea.Batch(cmd, applyCopies(edit.copies, true), m.toast("Undid "+edit.label))
+}
+
+// redo makes the latest undone edit in the selected space again.
+func (m *model) redo() tea.Cmd {
+	edit, ok := popEdit(&m.redoStack, m.selectedSpace.ID)
+	if !ok {
+		return m.toast("Nothing to redo")
+	}
+	m.undoStack = append(m.undoStack, edit)
+	cmd := m.applyChanges(edit.changes, false)
+	return tea.Batch(cmd, applyCopies(edit.copies, false), m.toast("Redid "+edit.label))
+}
+
+// popEdit takes the newest edit for a space off a stack. Edits in other
+// spaces stay where they are, to be undone from their own space.
+func popEdit(stack *[]undoEdit, spaceID string) (undoEdit, bool) {
+	for i := len(*stack) - 1; i >= 0; i-- {
+		if edit := (*stack)[i]; edit.spaceID == spaceID {
+			*stack = append((*stack)[:i:i], (*stack)[i+1:]...)
+			return edit, true
+		}
+	}
+	return undoEdit{}, false
+}
+
+// applyChanges puts cards into their before state (when reverting) or
+// their after state, locally and then with the inverse API calls. Removed
+// cards are brought back with Kinopio's restore.
+func (m *model) applyChanges(changes []cardChange, revert bool) tea.Cmd {
+	var (
+		cmds    []tea.Cmd
+		updates []map[string]interface{}
+		updated []Card
+	)
+	for _, change := range changes {
+		from, to := change.before, change.after
+		if revert {
+			from, to = to, from
+		}
+		switch {
+		case to == nil:
+			m.removeLocalCard(from.ID)
+			cmds = append(cmds, removeCard(from.ID))
+		case from == nil:
+			card := *to
+			m.selectedSpace.Cards = append(m.selectedSpace.Cards, card)
+			cmds = append(cmds, apiCmd(func(ctx context.Context) error { return api.RestoreCard(ctx, card.ID) }))
+		default:
+			if fields := cardDiff(*from, *to); len(fields) > 1 {
+				updates = append(updates, fields)
+				updated = append(updated, *to)
+			}
+		}
+	}
+	if len(updates) > 0 {
+		cmds = append(cmds, updateCards(updates))
+	}
+	m.replaceCards(updated...)
+	m.refreshSpaceView()
+	return tea.Batch(cmds...)
+}
+
+// applyCopies removes the cards an edit created in other spaces (when
+// reverting) or restores them. They aren't in the open space, so there's
+// nothing to change locally.
+func applyCopies(ids []string, revert bool) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, id := range ids {
+		id := id
+		if revert {
+			cmds = append(cmds, removeCard(id))
+		} else {
+			cmds = append(cmds, apiCmd(func(ctx context.Context) error { return api.RestoreCard(ctx, id) }))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// cardDiff returns the fields to PATCH to turn card from into card to,
+// always including the id.
+func cardDiff(from, to Card) map[string]interface{} {
+	fields := map[string]interface{}{"id": to.ID}
+	if from.Name != to.Name {
+		fields["name"] = to.Name
+	}
+	if from.X != to.X || from.Y != to.Y {
+		fields["x"], fields["y"] = to.X, to.Y
+	}
+	if from.BackgroundColor != to.BackgroundColor {
+		fields["backgroundColor"] = to.BackgroundColor
+	}
+	if from.IsComment != to.IsComment {
+		fields["isComment"] = to.IsComment
+	}
+	return fields
+}
+
+// refreshSpaceView redraws whichever view of the space's cards is showing,
+// after cards have been added or removed.
+func (m *model) refreshSpaceView() {
+	switch m.currentView {
+	case "cards":
+		m.setCardItems()
+	case "cardDetails":
+		if card, ok := m.cardByID(m.selectedCard.ID); ok {
+			m.selectedCard = card
+			m.showCardDetails()
+		} else {
+			m.showCards()
+		}
+	case "kanban":
+		if card, ok := m.kanban.selectedCard(); ok {
+			m.refreshKanban(card.ID)
+		} else {
+			m.showKanban()
+		}
+	case "canvas":
+		m.showCanvas()
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCardDiff(t *testing.T) {
+	card := Card{ID: "a", Name: "name", X: 10, Y: 20, BackgroundColor: "#fff"}
+	with := func(change func(*Card)) Card {
+		c := card
+		change(&c)
+		return c
+	}
+	tests := []struct {
+		name string
+		to   Card
+		want map[string]interface{}
+	}{
+		{"unchanged", card, map[string]interface{}{"id": "a"}},
+		{"name", with(func(c *Card) { c.Name = "new" }), map[string]interface{}{"id": "a", "name": "new"}},
+		{"moved in x only", with(func(c *Card) { c.X = 30 }), map[string]interface{}{"id": "a", "x": 30, "y": 20}},
+		{"color cleared", with(func(c *Card) { c.BackgroundColor = "" }), map[string]interface{}{"id": "a", "backgroundColor": ""}},
+		{"comment", with(func(c *Card) { c.IsComment = true }), map[string]interface{}{"id": "a", "isComment": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cardDiff(card, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cardDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPopEdit(t *testing.T) {
+	stack := []undoEdit{{label: "one", spaceID: "a"}, {label: "two", spaceID: "b"}, {label: "three", spaceID: "a"}}
+	edit, ok := popEdit(&stack, "a")
+	if !ok || edit.label != "three" {
+		t.Fatalf("popEdit() = %q, %v, want three", edit.label, ok)
+	}
+	edit, _ = popEdit(&stack, "a")
+	if edit.label != "one" {
+		t.Errorf("second popEdit() = %q, want one", edit.label)
+	}
+	if _, ok := popEdit(&stack, "a"); ok {
+		t.Errorf("popEdit() found an edit after space a's ran out")
+	}
+	if len(stack) != 1 || stack[0].label != "two" {
+		t.Errorf("stack = %+v, want just space b's edit", stack)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// viewFrame is a view on the view stack, with the list's state when it
+// was left, so going back puts the cursor and filter where they were.
+type viewFrame struct {
+	loc     location
+	itemKey string // The selected item, as itemKey identifies it
+	index   int
+	filter  string // The list filter that was applied, if any
+}
+
+// frame captures the current view and the state of its list.
+func (m *model) frame() viewFrame {
+	f := viewFrame{loc: m.location(), index: m.list.Index()}
+	if item := m.list.SelectedItem(); item != nil {
+		f.itemKey = itemKey(item)
+	}
+	if m.list.FilterState() == list.FilterApplied {
+		f.filter = m.list.FilterValue()
+	}
+	return f
+}
+
+// cursorKey identifies a list whose cursor is remembered: a view, in a
+// space, box or tag where it has one.
+type cursorKey struct {
+	view, spaceID, boxID, tag string
+}
+
+// cursorPosition is where a list's cursor was when the user left it.
+type cursorPosition struct {
+	itemKey string
+	index   int
+}
+
+func cursorKeyFor(loc location) cursorKey {
+	return cursorKey{view: loc.view, spaceID: loc.spaceID, boxID: loc.boxID, tag: loc.tag}
+}
+
+// showsList reports whether view is drawn with the shared list, rather
+// than a table or board of its own.
+func showsList(view string) bool {
+	switch view {
+	case "cardDetails", "kanban", "canvas":
+		return false
+	}
+	return true
+}
+
+// recordView keeps the view stack in step with where the user went: a new
+// view pushes the one they came from, and returning to a view on the stack
+// pops everything above it. The spaces list is always the bottom.
+//
+// It also remembers the cursor of the list left behind, and puts the
+// cursor of the list arrived at back where it was last time, however the
+// user got there.
+func (m *model) recordView(before viewFrame) {
+	now := m.location()
+	if now == before.loc {
+		return
+	}
+	if m.cursors == nil {
+		m.cursors = map[cursorKey]cursorPosition{}
+	}
+	if showsList(before.loc.view) {
+		m.cursors[cursorKeyFor(before.loc)] = cursorPosition{itemKey: before.itemKey, index: before.index}
+	}
+	if pos, ok := m.cursors[cursorKeyFor(now)]; ok && showsList(now.view) {
+		m.selectItem(pos.itemKey, pos.index)
+	}
+	m.pushView(before, now)
+}
+
+func (m *model) pushView(before viewFrame, now location) {
+	for i := len(m.views) - 1; i >= 0; i-- {
+		if m.views[i].loc == now {
+			m.views = m.views[:i]
+			return
+		}
+	}
+	if now.view == "list" {
+		m.views = nil
+		return
+	}
+	m.views = append(m.views, before)
+}
+
+// popView goes back to the view underneath the current one, restoring its
+// cursor and filter.
+func (m *model) popView() tea.Cmd {
+	if len(m.views) == 0 {
+		if m.currentView != "list" {
+			m.showSpaces()
+		}
+		return nil
+	}
+	f := m.views[len(m.views)-1]
+	if f.loc.spaceID != "" && f.loc.spaceID != m.selectedSpace.ID {
+		m.pendingLocation = &f.loc
+		return m.openSpace(Space{ID: f.loc.spaceID})
+	}
+	// Leave m.here alone so history still records the step back.
+	here := m.here
+	m.restoreLocation(f.loc)
+	m.here = here
+	if f.filter != "" {
+		m.refilter(f)
+		return nil
+	}
+	m.selectItem(f.itemKey, f.index)
+	return nil
+}
+
+// selectItem puts the cursor on the item with key, or at index if it's
+// gone.
+func (m *model) selectItem(key string, index int) {
+	items := m.list.VisibleItems()
+	for i, item := range items {
+		if itemKey(item) == key {
+			m.list.Select(i)
+			return
+		}
+	}
+	if len(items) > 0 {
+		m.list.Select(min(index, len(items)-1))
+	}
+}
+
+// refilter types a frame's filter into the list again. The list filters
+// in the background, so finishRefilter accepts it once the matches are in.
+func (m *model) refilter(f viewFrame) {
+	m.list.ResetFilter()
+	start, _ := parseKey(m.list.KeyMap.Filter.Keys()[0])
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(start)
+	m.listCmds = append(m.listCmds, cmd)
+	m.list, cmd = m.list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(f.filter)})
+	m.listCmds = append(m.listCmds, cmd)
+	m.refiltering = &f
+}
+
+// finishRefilter applies the filter refilter typed and puts the cursor
+// back, unless the user has moved on.
+func (m *model) finishRefilter() {
+	f := m.refiltering
+	m.refiltering = nil
+	if f.loc != m.location() || m.list.FilterState() != list.Filtering {
+		return
+	}
+	accept, _ := parseKey(m.list.KeyMap.AcceptWhileFiltering.Keys()[0])
+	m.list, _ = m.list.Update(accept)
+	m.selectItem(f.itemKey, f.index)
+}
+
+var viewNames = map[string]string{
+	"list":          "Spaces",
+	"allTags":       "Tags",
+	"tagCards":      "Tagged cards",
+	"boxes":         "Boxes",
+	"connections":   "Connections",
+	"graph":         "Graph",
+	"spaceActions":  "Actions",
+	"tags":          "Tags",
+	"kanban":        "Kanban",
+	"canvas":        "Canvas",
+	"removed":       "Removed cards",
+	"duplicates":    "Duplicates",
+	"links":         "Links",
+	"bulkActions":   "Bulk actions",
+	"explore":       "Explore",
+	"templates":     "New space",
+	"notifications": "Notifications",
+	"account":       "Account",
+}
+
+// crumb names a location for the breadcrumb.
+func (m *model) crumb(loc location) string {
+	switch loc.view {
+	case "details":
+		return m.spaceName(loc.spaceID)
+	case "cards":
+		if loc.tag != "" {
+			return "#" + loc.tag
+		}
+		for _, box := range m.selectedSpace.Boxes {
+			if box.ID == loc.boxID {
+				return box.Name
+			}
+		}
+		return "Cards"
+	case "cardDetails":
+		if card, ok := m.cardByID(loc.cardID); ok {
+			return ansi.Truncate(firstLine(card.Name), 30, "…")
+		}
+		return "Card"
+	}
+	if name, ok := viewNames[loc.view]; ok {
+		return name
+	}
+	return loc.view
+}
+
+// spaceName looks up a space's name by ID.
+func (m *model) spaceName(id string) string {
+	if id == m.selectedSpace.ID {
+		return m.selectedSpace.Name
+	}
+	for _, space := range m.spaces {
+		if space.ID == id {
+			return space.Name
+		}
+	}
+	return "Space"
+}
+
+// breadcrumb traces the view stack, e.g. "Spaces › Project X › Cards".
+// The open space is named even when it was entered somewhere other than
+// its details, such as from search.
+func (m *model) breadcrumb() string {
+	var crumbs []string
+	named := false
+	for _, loc := range append(m.viewLocations(), m.location()) {
+		if loc.spaceID == m.selectedSpace.ID && !named {
+			named = true
+			if loc.view != "details" {
+				crumbs = append(crumbs, m.selectedSpace.Name)
+			}
+		}
+		crumbs = append(crumbs, m.crumb(loc))
+	}
+	crumbs[len(crumbs)-1] = headerNameStyle.Render(crumbs[len(crumbs)-1])
+	return strings.Join(crumbs, " › ")
+}
+
+func (m *model) viewLocations() []location {
+	locs := make([]location, len(m.views))
+	for i, f := range m.views {
+		locs[i] = f.loc
+	}
+	return locs
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// warmRefreshInterval is how often warm spaces are re-fetched in the
+// background.
+const warmRefreshInterval = 2 * time.Minute
+
+type spaceWarmedMsg struct {
+	Space Space
+}
+
+type warmTickMsg struct{}
+
+func (m *model) isWarm(spaceID string) bool {
+	return slices.Contains(m.state.WarmSpaces, spaceID)
+}
+
+// toggleWarm marks the selected space as warm, or stops keeping it warm.
+func (m *model) toggleWarm() tea.Cmd {
+	item, ok := m.list.SelectedItem().(listItem)
+	if !ok {
+		return nil
+	}
+	id := item.Space.ID
+	var cmd tea.Cmd
+	if i := slices.Index(m.state.WarmSpaces, id); i >= 0 {
+		m.state.WarmSpaces = slices.Delete(m.state.WarmSpaces, i, i+1)
+		delete(m.spaceCache, id)
+	} else {
+		m.state.WarmSpaces = append(m.state.WarmSpaces, id)
+		cmd = warmSpace(id)
+	}
+	m.showSpaces()
+	if err := m.state.save(); err != nil {
+		return func() tea.Msg { return err }
+	}
+	return cmd
+}
+
+// warmSpaces fetches every warm space in the background.
+func (m *model) warmSpaces() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, id := range m.state.WarmSpaces {
+		cmds = append(cmds, warmSpace(id))
+	}
+	return tea.Batch(cmds...)
+}
+
+// warmSpace fetches a space into the cache. Failures are ignored: the
+// space is simply fetched normally when opened.
+func warmSpace(spaceID string) tea.Cmd {
+	return func() tea.Msg {
+		space, err := api.Space(programCtx, spaceID)
+		if err != nil {
+			return nil
+		}
+		return spaceWarmedMsg{Space: space}
+	}
+}
+
+func warmTick() tea.Cmd {
+	return tea.Tick(warmRefreshInterval, func(time.Time) tea.Msg { return warmTickMsg{} })
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	waybackSaveURL = "https://web.archive.org/save/"
+	waybackTimeout = 90 * time.Second
+)
+
+type cardsArchivedMsg struct {
+	cards  []Card // Cards with archive links appended
+	failed []string
+}
+
+// archiveURL asks the Wayback Machine to save url and returns the link to
+// the snapshot.
+func archiveURL(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(waybackSaveURL + url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	if location := resp.Header.Get("Content-Location"); strings.HasPrefix(location, "/web/") {
+		return "https://web.archive.org" + location, nil
+	}
+	// The save endpoint redirects to the new snapshot.
+	return resp.Request.URL.String(), nil
+}
+
+// archiveCards archives every URL in cards and appends the snapshot links
+// to each card's text, saving the cards as it goes.
+func archiveCards(cards []Card) tea.Cmd {
+	return func() tea.Msg {
+		client := &http.Client{Timeout: waybackTimeout}
+		var msg cardsArchivedMsg
+		for _, card := range cards {
+			var links []string
+			for _, url := range cardURLs(card.Name) {
+				if strings.HasPrefix(url, "https://web.archive.org/") {
+					continue
+				}
+				link, err := archiveURL(client, url)
+				if err != nil {
+					msg.failed = append(msg.failed, err.Error())
+					continue
+				}
+				links = append(links, link)
+			}
+			if len(links) == 0 {
+				continue
+			}
+			card.Name += "\n\nArchived: " + strings.Join(links, " ")
+			if err := api.UpdateCard(programCtx, map[string]interface{}{"id": card.ID, "name": card.Name}); err != nil {
+				return err
+			}
+			msg.cards = append(msg.cards, card)
+		}
+		return msg
+	}
+}
+
+// cardsWithURLs returns the cards that link to something worth archiving.
+func cardsWithURLs(cards []Card) []Card {
+	var linked []Card
+	for _, card := range cards {
+		for _, url := range cardURLs(card.Name) {
+			if !strings.HasPrefix(url, "https://web.archive.org/") {
+				linked = append(linked, card)
+				break
+			}
+		}
+	}
+	return linked
+}
+
+// confirmArchive archives the open card's links, or every linked card in
+// the space from the cards list. Each card gets its snapshot links
+// appended, so this asks first.
+func (m *model) confirmArchive() {
+	cards := cardsWithURLs(m.selectedSpace.Cards)
+	if m.currentView == "cardDetails" {
+		cards = cardsWithURLs([]Card{m.selectedCard})
+	}
+	if len(cards) == 0 {
+		return
+	}
+	message := fmt.Sprintf("Archive the links in %s to the Wayback Machine? Archive links will be appended to each card.", plural(len(cards), "card"))
+	m.askConfirm(message, false, func() tea.Cmd {
+		m.loading = true
+		return tea.Batch(archiveCards(cards), m.spinner.Tick)
+	})
+}